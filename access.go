@@ -2,13 +2,15 @@ package aristoteles
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/odysseia-greek/aristoteles/models"
 )
 
 type AccessImpl struct {
-	es *elasticsearch.Client
+	es         *elasticsearch.Client
+	instrument *instrument
 }
 
 func NewAccessImpl(suppliedClient *elasticsearch.Client) (*AccessImpl, error) {
@@ -19,36 +21,54 @@ func NewAccessImpl(suppliedClient *elasticsearch.Client) (*AccessImpl, error) {
 }
 
 func (a *AccessImpl) CreateRole(name string, roleRequest models.CreateRoleRequest) (bool, error) {
+	return a.CreateRoleContext(context.Background(), name, roleRequest)
+}
+
+func (a *AccessImpl) CreateRoleContext(ctx context.Context, name string, roleRequest models.CreateRoleRequest) (created bool, err error) {
+	ctx, op := a.instrument.begin(ctx, "create_role", "")
+	defer func() { op.end(err) }()
+
 	jsonRole, err := roleRequest.Marshal()
 	if err != nil {
 		return false, err
 	}
 	buffer := bytes.NewBuffer(jsonRole)
-	res, err := a.es.Security.PutRole(name, buffer)
+	res, err := a.es.Security.PutRole(name, buffer, a.es.Security.PutRole.WithContext(ctx))
+	op.response(res)
 	if err != nil {
 		return false, err
 	}
+	defer res.Body.Close()
 
 	if res.IsError() {
-		return false, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return false, newElasticError(res)
 	}
 
 	return true, nil
 }
 
 func (a *AccessImpl) CreateUser(name string, userCreation models.CreateUserRequest) (bool, error) {
+	return a.CreateUserContext(context.Background(), name, userCreation)
+}
+
+func (a *AccessImpl) CreateUserContext(ctx context.Context, name string, userCreation models.CreateUserRequest) (created bool, err error) {
+	ctx, op := a.instrument.begin(ctx, "create_user", "")
+	defer func() { op.end(err) }()
+
 	jsonUser, err := userCreation.Marshal()
 	if err != nil {
 		return false, err
 	}
 	buffer := bytes.NewBuffer(jsonUser)
-	res, err := a.es.Security.PutUser(name, buffer)
+	res, err := a.es.Security.PutUser(name, buffer, a.es.Security.PutUser.WithContext(ctx))
+	op.response(res)
 	if err != nil {
 		return false, err
 	}
+	defer res.Body.Close()
 
 	if res.IsError() {
-		return false, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return false, newElasticError(res)
 	}
 
 	return true, nil
@@ -1,6 +1,7 @@
 package aristoteles
 
 import (
+	"context"
 	"github.com/odysseia-greek/aristoteles/models"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -91,6 +92,33 @@ func TestCreateUser(t *testing.T) {
 		assert.True(t, sut)
 	})
 
+	t.Run("ContextCancelled", func(t *testing.T) {
+		config := models.Config{
+			Service:     "http://localhost:9200",
+			Username:    "",
+			Password:    "",
+			ElasticCERT: "",
+		}
+		testClient, err := NewClient(config)
+		assert.Nil(t, err)
+
+		userRequest := models.CreateUserRequest{
+			Password: "password",
+			Roles:    []string{"admin"},
+			FullName: "Alexandros Megalos",
+			Email:    "lex@megalos.com",
+			Metadata: nil,
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		sut, err := testClient.Access().CreateUserContext(ctx, name, userRequest)
+		assert.NotNil(t, err)
+		assert.False(t, sut)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		file := "createUser"
 		status := 502
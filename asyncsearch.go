@@ -0,0 +1,213 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/odysseia-greek/aristoteles/models"
+	"io"
+	"time"
+)
+
+const defaultAsyncPoll = time.Second
+
+// AsyncSearchConfig configures AsyncSearch, the zero value keeps the defaults of elasticsearch: wait one second for the
+// search to finish and keep the results for five days.
+type AsyncSearchConfig struct {
+	// WaitForCompletion is how long the submit waits for the search, a search done within it is not stored unless
+	// KeepOnCompletion is set.
+	WaitForCompletion time.Duration
+	// KeepAlive is how long elasticsearch keeps the search and its results after the submit.
+	KeepAlive        time.Duration
+	KeepOnCompletion bool
+}
+
+func (q *QueryImpl) AsyncSearch(index string, request map[string]interface{}, config AsyncSearchConfig) (*models.AsyncSearchResponse, error) {
+	return q.AsyncSearchContext(context.Background(), index, request, config)
+}
+
+// AsyncSearchContext submits request to _async_search. The response holds the id to poll while the search runs, or
+// the final results when it finished within WaitForCompletion.
+func (q *QueryImpl) AsyncSearchContext(ctx context.Context, index string, request map[string]interface{}, config AsyncSearchConfig) (result *models.AsyncSearchResponse, err error) {
+	ctx, op := q.instrument.begin(ctx, "async_search_submit", index)
+	defer func() { op.end(err) }()
+
+	query, err := toBuffer(request)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []func(*esapi.AsyncSearchSubmitRequest){
+		q.es.AsyncSearch.Submit.WithContext(ctx),
+		q.es.AsyncSearch.Submit.WithIndex(index),
+		q.es.AsyncSearch.Submit.WithBody(&query),
+		q.es.AsyncSearch.Submit.WithTrackTotalHits(true),
+	}
+	if config.WaitForCompletion > 0 {
+		opts = append(opts, q.es.AsyncSearch.Submit.WithWaitForCompletionTimeout(config.WaitForCompletion))
+	}
+	if config.KeepAlive > 0 {
+		opts = append(opts, q.es.AsyncSearch.Submit.WithKeepAlive(config.KeepAlive))
+	}
+	if config.KeepOnCompletion {
+		opts = append(opts, q.es.AsyncSearch.Submit.WithKeepOnCompletion(true))
+	}
+
+	res, err := q.es.AsyncSearch.Submit(opts...)
+	op.response(res)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = parseAsyncSearch(res)
+	if err != nil {
+		return nil, err
+	}
+	op.result(result.Response.Hits.Total.Value, result.Response.Took, len(result.Response.Hits.Hits))
+
+	return result, nil
+}
+
+func (q *QueryImpl) AsyncSearchStatus(id string) (*models.AsyncSearchStatus, error) {
+	return q.AsyncSearchStatusContext(context.Background(), id)
+}
+
+// AsyncSearchStatusContext returns whether the async search id is still running without fetching its results.
+func (q *QueryImpl) AsyncSearchStatusContext(ctx context.Context, id string) (status *models.AsyncSearchStatus, err error) {
+	ctx, op := q.instrument.begin(ctx, "async_search_status", "")
+	defer func() { op.end(err) }()
+
+	res, err := q.es.AsyncSearch.Status(id, q.es.AsyncSearch.Status.WithContext(ctx))
+	op.response(res)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newElasticError(res)
+	}
+
+	var asyncStatus models.AsyncSearchStatus
+	if err := json.NewDecoder(res.Body).Decode(&asyncStatus); err != nil {
+		return nil, err
+	}
+
+	return &asyncStatus, nil
+}
+
+func (q *QueryImpl) AsyncSearchResult(id string) (*models.AsyncSearchResponse, error) {
+	return q.AsyncSearchResultContext(context.Background(), id)
+}
+
+// AsyncSearchResultContext returns the results of the async search id, partial while it is running.
+func (q *QueryImpl) AsyncSearchResultContext(ctx context.Context, id string) (*models.AsyncSearchResponse, error) {
+	return q.getAsyncSearch(ctx, id, 0)
+}
+
+func (q *QueryImpl) DeleteAsyncSearch(id string) error {
+	return q.DeleteAsyncSearchContext(context.Background(), id)
+}
+
+// DeleteAsyncSearchContext cancels the async search id when it is running and deletes its results.
+func (q *QueryImpl) DeleteAsyncSearchContext(ctx context.Context, id string) (err error) {
+	ctx, op := q.instrument.begin(ctx, "async_search_delete", "")
+	defer func() { op.end(err) }()
+
+	res, err := q.es.AsyncSearch.Delete(id, q.es.AsyncSearch.Delete.WithContext(ctx))
+	op.response(res)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newElasticError(res)
+	}
+
+	return nil
+}
+
+// WaitAsyncSearch waits without a deadline, use WaitAsyncSearchContext to get partial results when waiting too long.
+func (q *QueryImpl) WaitAsyncSearch(id string, poll time.Duration) (*models.AsyncSearchResponse, error) {
+	return q.WaitAsyncSearchContext(context.Background(), id, poll)
+}
+
+// WaitAsyncSearchContext waits for the async search id to finish, asking elasticsearch to hold every poll for up to
+// poll, which defaults to a second. When ctx is done first the latest partial results are returned together with the
+// error of ctx, the search keeps running and can be waited for again or deleted.
+func (q *QueryImpl) WaitAsyncSearchContext(ctx context.Context, id string, poll time.Duration) (*models.AsyncSearchResponse, error) {
+	if poll <= 0 {
+		poll = defaultAsyncPoll
+	}
+
+	for ctx.Err() == nil {
+		result, err := q.getAsyncSearch(ctx, id, poll)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return nil, err
+		}
+
+		if !result.IsRunning {
+			return result, nil
+		}
+	}
+
+	partialCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	partial, err := q.getAsyncSearch(partialCtx, id, 0)
+	if err != nil {
+		return nil, ctx.Err()
+	}
+
+	return partial, ctx.Err()
+}
+
+func (q *QueryImpl) getAsyncSearch(ctx context.Context, id string, wait time.Duration) (result *models.AsyncSearchResponse, err error) {
+	ctx, op := q.instrument.begin(ctx, "async_search_get", "")
+	defer func() { op.end(err) }()
+
+	opts := []func(*esapi.AsyncSearchGetRequest){
+		q.es.AsyncSearch.Get.WithContext(ctx),
+	}
+	if wait > 0 {
+		opts = append(opts, q.es.AsyncSearch.Get.WithWaitForCompletionTimeout(wait))
+	}
+
+	res, err := q.es.AsyncSearch.Get(id, opts...)
+	op.response(res)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = parseAsyncSearch(res)
+	if err != nil {
+		return nil, err
+	}
+	op.result(result.Response.Hits.Total.Value, result.Response.Took, len(result.Response.Hits.Hits))
+
+	return result, nil
+}
+
+func parseAsyncSearch(res *esapi.Response) (*models.AsyncSearchResponse, error) {
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newElasticError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := models.UnmarshalAsyncSearchResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,154 @@
+package aristoteles
+
+import (
+	"context"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAsyncSearch(t *testing.T) {
+	index := "herodotos,plato"
+	id := "FmRldE8zREVEUzA2ZVpUeGs2ejJFUFEaMkZ5QTVrSTZSaVN3WlNFVmtlWHJ5Zzo2MTYy"
+	config := models.Config{
+		Service: "http://localhost:9200",
+	}
+	request := NewBuilderImpl().Aggregate("authors", "author")
+
+	t.Run("Submit", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "asyncSearchRunning", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().AsyncSearch(index, request, AsyncSearchConfig{
+			WaitForCompletion: 2 * time.Second,
+			KeepAlive:         time.Hour,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, id, sut.ID)
+		assert.True(t, sut.IsRunning)
+		assert.True(t, sut.IsPartial)
+		assert.Equal(t, 1, len(sut.Response.Aggregations.Buckets("authors")))
+
+		requests := transport.Requests()
+		assert.Equal(t, http.MethodPost, requests[0].Method)
+		assert.Equal(t, "/"+index+"/_async_search", requests[0].URL.Path)
+		assert.Contains(t, requests[0].JSON(), "aggs")
+	})
+
+	t.Run("Status", func(t *testing.T) {
+		file := "asyncSearchStatus"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().AsyncSearchStatus(id)
+		assert.Nil(t, err)
+		assert.True(t, sut.IsRunning)
+		assert.Equal(t, 0, sut.CompletionStatus)
+		assert.Equal(t, int64(1), sut.Shards.Successful)
+	})
+
+	t.Run("Result", func(t *testing.T) {
+		file := "asyncSearchDone"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().AsyncSearchResult(id)
+		assert.Nil(t, err)
+		assert.False(t, sut.IsRunning)
+		assert.Equal(t, int64(4873), sut.Response.Hits.Total.Value)
+		assert.Equal(t, 2, len(sut.Response.Aggregations.Buckets("authors")))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		file := "asyncSearchNotFound"
+		status := 404
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().AsyncSearchResult(id)
+		assert.Nil(t, sut)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "deleteIndex", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		err = testClient.Query().DeleteAsyncSearch(id)
+		assert.Nil(t, err)
+		assert.Equal(t, http.MethodDelete, transport.Requests()[0].Method)
+		assert.Equal(t, "/_async_search/"+id, transport.Requests()[0].URL.Path)
+	})
+
+	t.Run("WaitUntilDone", func(t *testing.T) {
+		transport := NewSequentialTransport(
+			MockResponse{Fixture: "asyncSearchRunning", StatusCode: 200},
+			MockResponse{Fixture: "asyncSearchRunning", StatusCode: 200},
+			MockResponse{Fixture: "asyncSearchDone", StatusCode: 200},
+		)
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().WaitAsyncSearch(id, 0)
+		assert.Nil(t, err)
+		assert.False(t, sut.IsRunning)
+		assert.False(t, sut.IsPartial)
+		assert.Equal(t, 3, len(transport.Requests()))
+		assert.Equal(t, "/_async_search/"+id, transport.Requests()[0].URL.Path)
+	})
+
+	t.Run("PartialOnDeadline", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "asyncSearchRunning", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		sut, err := testClient.Query().WaitAsyncSearchContext(ctx, id, 10*time.Millisecond)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotNil(t, sut)
+		assert.True(t, sut.IsPartial)
+		assert.Equal(t, int64(1120), sut.Response.Hits.Total.Value)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		testClient, err := NewClient(config)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		submitted, err := testClient.Query().AsyncSearchContext(ctx, index, request, AsyncSearchConfig{})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, submitted)
+
+		status, err := testClient.Query().AsyncSearchStatusContext(ctx, id)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, status)
+
+		result, err := testClient.Query().AsyncSearchResultContext(ctx, id)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, result)
+
+		err = testClient.Query().DeleteAsyncSearchContext(ctx, id)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("WaitFailed", func(t *testing.T) {
+		file := "serviceDown"
+		status := 502
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().WaitAsyncSearch(id, time.Millisecond)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+}
@@ -0,0 +1,170 @@
+package aristoteles
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without contacting elasticsearch while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen fails every request until the open timeout passes.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe through, its outcome closes or opens the circuit again.
+	CircuitHalfOpen
+)
+
+func (c CircuitState) String() string {
+	switch c {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures when the circuit breaker opens. Connection errors, timeouts and 5xx responses count
+// as failures, requests cancelled by the caller do not count.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit, defaults to 5.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a probe is let through, defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// OnStateChange is called after every transition, for example to export the state. It runs while the breaker is
+	// locked and has to return quickly.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker fails requests fast with ErrCircuitOpen after repeated failures. The state is reported in the
+// Circuit field of Health().Info().
+func WithCircuitBreaker(breaker CircuitBreakerConfig) Option {
+	return func(s *settings) {
+		if breaker.FailureThreshold <= 0 {
+			breaker.FailureThreshold = defaultFailureThreshold
+		}
+		if breaker.OpenTimeout <= 0 {
+			breaker.OpenTimeout = defaultOpenTimeout
+		}
+
+		s.circuit = &circuitBreaker{config: breaker, now: time.Now}
+	}
+}
+
+type circuitBreaker struct {
+	sync.Mutex
+	config   CircuitBreakerConfig
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+// State returns the current state, an open circuit whose timeout passed is reported as half-open.
+func (c *circuitBreaker) State() CircuitState {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.state == CircuitOpen && c.now().Sub(c.openedAt) >= c.config.OpenTimeout {
+		return CircuitHalfOpen
+	}
+
+	return c.state
+}
+
+func (c *circuitBreaker) allow() bool {
+	c.Lock()
+	defer c.Unlock()
+
+	switch c.state {
+	case CircuitOpen:
+		if c.now().Sub(c.openedAt) < c.config.OpenTimeout {
+			return false
+		}
+		c.transition(CircuitHalfOpen)
+		c.probing = true
+		return true
+	case CircuitHalfOpen:
+		if c.probing {
+			return false
+		}
+		c.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (c *circuitBreaker) record(success bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.probing = false
+
+	if success {
+		c.failures = 0
+		c.transition(CircuitClosed)
+		return
+	}
+
+	c.failures++
+	if c.state == CircuitHalfOpen || c.failures >= c.config.FailureThreshold {
+		c.openedAt = c.now()
+		c.transition(CircuitOpen)
+	}
+}
+
+// cancel releases the probe of a half-open circuit when its outcome says nothing about elasticsearch.
+func (c *circuitBreaker) cancel() {
+	c.Lock()
+	defer c.Unlock()
+
+	c.probing = false
+}
+
+func (c *circuitBreaker) transition(to CircuitState) {
+	from := c.state
+	c.state = to
+	if from != to && c.config.OnStateChange != nil {
+		c.config.OnStateChange(from, to)
+	}
+}
+
+type circuitTransport struct {
+	next    http.RoundTripper
+	circuit *circuitBreaker
+}
+
+func (t *circuitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.circuit.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	res, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		t.circuit.cancel()
+	case err != nil:
+		t.circuit.record(false)
+	default:
+		t.circuit.record(res.StatusCode < http.StatusInternalServerError)
+	}
+
+	return res, err
+}
@@ -0,0 +1,88 @@
+package aristoteles
+
+import (
+	"errors"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	index := "test"
+	config := models.Config{
+		Service: "http://localhost:9200",
+	}
+
+	t.Run("OpensAfterFailures", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "serviceDown", StatusCode: 503})
+		var transitions []CircuitState
+		testClient, err := NewClient(config,
+			WithMaxRetries(0),
+			WithCircuitBreaker(CircuitBreakerConfig{
+				FailureThreshold: 2,
+				OpenTimeout:      time.Minute,
+				OnStateChange:    func(from, to CircuitState) { transitions = append(transitions, to) },
+			}),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+			assert.NotNil(t, err)
+		}
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.True(t, errors.Is(err, ErrCircuitOpen))
+		assert.Equal(t, 2, len(transport.Requests()))
+		assert.Equal(t, []CircuitState{CircuitOpen}, transitions)
+
+		health := testClient.Health().Info()
+		assert.False(t, health.Healthy)
+		assert.Equal(t, "open", health.Circuit)
+	})
+
+	t.Run("HalfOpenProbe", func(t *testing.T) {
+		now := time.Now()
+		circuit := &circuitBreaker{
+			config: CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second},
+			now:    func() time.Time { return now },
+		}
+
+		assert.True(t, circuit.allow())
+		circuit.record(false)
+		assert.Equal(t, CircuitOpen, circuit.State())
+		assert.False(t, circuit.allow())
+
+		now = now.Add(time.Second)
+		assert.Equal(t, CircuitHalfOpen, circuit.State())
+		assert.True(t, circuit.allow())
+		assert.False(t, circuit.allow())
+
+		circuit.record(false)
+		assert.Equal(t, CircuitOpen, circuit.State())
+
+		now = now.Add(time.Second)
+		assert.True(t, circuit.allow())
+		circuit.record(true)
+		assert.Equal(t, CircuitClosed, circuit.State())
+		assert.True(t, circuit.allow())
+	})
+
+	t.Run("ClientErrorsKeepCircuitClosed", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "serviceDown", StatusCode: 404})
+		testClient, err := NewClient(config,
+			WithCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1}),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		for i := 0; i < 3; i++ {
+			_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+			assert.True(t, IsNotFound(err))
+		}
+
+		assert.Equal(t, "closed", testClient.Health().Info().Circuit)
+	})
+}
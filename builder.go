@@ -116,6 +116,155 @@ func (b *BuilderImpl) FilteredAggregate(term, queryWord, aggregate, field string
 	return query
 }
 
+// Sort adds a sort on fields in priority order to request and returns it.
+func (b *BuilderImpl) Sort(request map[string]interface{}, fields ...SortField) map[string]interface{} {
+	request["sort"] = sortBody(fields)
+
+	return request
+}
+
+// HighlightConfig configures the highlighting of a search. Numbers left at 0 and empty tags keep the defaults of
+// elasticsearch: fragments of 100 characters, at most 5 fragments and <em></em> tags.
+type HighlightConfig struct {
+	Fields            []string
+	FragmentSize      int
+	NumberOfFragments int
+	PreTags           []string
+	PostTags          []string
+}
+
+// Highlight adds highlighting to request and returns it, the fragments are returned in the Highlight of every hit.
+func (b *BuilderImpl) Highlight(request map[string]interface{}, config HighlightConfig) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, field := range config.Fields {
+		fields[field] = map[string]interface{}{}
+	}
+
+	highlight := map[string]interface{}{
+		"fields": fields,
+	}
+	if config.FragmentSize > 0 {
+		highlight["fragment_size"] = config.FragmentSize
+	}
+	if config.NumberOfFragments > 0 {
+		highlight["number_of_fragments"] = config.NumberOfFragments
+	}
+	if len(config.PreTags) > 0 {
+		highlight["pre_tags"] = config.PreTags
+	}
+	if len(config.PostTags) > 0 {
+		highlight["post_tags"] = config.PostTags
+	}
+
+	request["highlight"] = highlight
+
+	return request
+}
+
+// CollapseConfig configures the collapsing of hits on a keyword or numeric field with doc values, only the best hit of
+// every value is returned.
+type CollapseConfig struct {
+	Field string
+	// InnerHits returns more hits of every group, each with its own name, in the InnerHits of the collapsed hit.
+	InnerHits []InnerHitsConfig
+	// MaxConcurrentGroupSearches limits the searches for inner hits that run at once, 0 keeps the default.
+	MaxConcurrentGroupSearches int
+}
+
+// InnerHitsConfig configures one set of inner hits. Size 0 keeps the default of 3 hits.
+type InnerHitsConfig struct {
+	Name string
+	Size int
+	From int
+	Sort []SortField
+}
+
+// Collapse adds collapsing to request and returns it.
+func (b *BuilderImpl) Collapse(request map[string]interface{}, config CollapseConfig) map[string]interface{} {
+	collapse := map[string]interface{}{
+		"field": config.Field,
+	}
+
+	if len(config.InnerHits) > 0 {
+		innerHits := make([]interface{}, 0, len(config.InnerHits))
+		for _, inner := range config.InnerHits {
+			innerHit := map[string]interface{}{
+				"name": inner.Name,
+			}
+			if inner.Size > 0 {
+				innerHit["size"] = inner.Size
+			}
+			if inner.From > 0 {
+				innerHit["from"] = inner.From
+			}
+			if len(inner.Sort) > 0 {
+				innerHit["sort"] = sortBody(inner.Sort)
+			}
+			innerHits = append(innerHits, innerHit)
+		}
+		collapse["inner_hits"] = innerHits
+	}
+
+	if config.MaxConcurrentGroupSearches > 0 {
+		collapse["max_concurrent_group_searches"] = config.MaxConcurrentGroupSearches
+	}
+
+	request["collapse"] = collapse
+
+	return request
+}
+
+// CompletionSuggest suggests up to size values of a completion field starting with prefix, allowing for typos.
+func (b *BuilderImpl) CompletionSuggest(name, prefix, field string, size int) map[string]interface{} {
+	return suggest(name, map[string]interface{}{
+		"prefix": prefix,
+		"completion": map[string]interface{}{
+			"field":           field,
+			"size":            size,
+			"skip_duplicates": true,
+			"fuzzy": map[string]interface{}{
+				"fuzziness": "AUTO",
+			},
+		},
+	})
+}
+
+// TermSuggest suggests corrections for every term of text that is not found in field.
+func (b *BuilderImpl) TermSuggest(name, text, field string) map[string]interface{} {
+	return suggest(name, map[string]interface{}{
+		"text": text,
+		"term": map[string]interface{}{
+			"field":        field,
+			"suggest_mode": "missing",
+		},
+	})
+}
+
+// PhraseSuggest suggests a corrected version of text as a whole, based on the terms in field.
+func (b *BuilderImpl) PhraseSuggest(name, text, field string) map[string]interface{} {
+	return suggest(name, map[string]interface{}{
+		"text": text,
+		"phrase": map[string]interface{}{
+			"field": field,
+			"size":  5,
+			"highlight": map[string]interface{}{
+				"pre_tag":  "<em>",
+				"post_tag": "</em>",
+			},
+		},
+	})
+}
+
+// suggest wraps a suggester in a request that does not return any hits.
+func suggest(name string, suggester map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"size": 0,
+		"suggest": map[string]interface{}{
+			name: suggester,
+		},
+	}
+}
+
 func (b *BuilderImpl) SearchAsYouTypeIndex(searchWord string) map[string]interface{} {
 	return map[string]interface{}{
 		"mappings": map[string]interface{}{
@@ -128,6 +277,74 @@ func (b *BuilderImpl) SearchAsYouTypeIndex(searchWord string) map[string]interfa
 	}
 }
 
+// greekDiacritics lists every precomposed monotonic and polytonic form of the greek vowels and rho, with accents,
+// breathings, diaeresis or iota subscript, after the bare letter it folds to.
+var greekDiacritics = [][2]string{
+	{"α", "ΆάἀἁἂἃἄἅἆἇἈἉἊἋἌἍἎἏὰάᾀᾁᾂᾃᾄᾅᾆᾇᾈᾉᾊᾋᾌᾍᾎᾏᾰᾱᾲᾳᾴᾶᾷᾸᾹᾺΆᾼ"},
+	{"ε", "ΈέἐἑἒἓἔἕἘἙἚἛἜἝὲέῈΈ"},
+	{"η", "ΉήἠἡἢἣἤἥἦἧἨἩἪἫἬἭἮἯὴήᾐᾑᾒᾓᾔᾕᾖᾗᾘᾙᾚᾛᾜᾝᾞᾟῂῃῄῆῇῊΉῌ"},
+	{"ι", "ΊΐΪίϊἰἱἲἳἴἵἶἷἸἹἺἻἼἽἾἿὶίιῐῑῒΐῖῗῘῙῚΊ"},
+	{"ο", "ΌόὀὁὂὃὄὅὈὉὊὋὌὍὸόῸΌ"},
+	{"ρ", "ῤῥῬ"},
+	{"υ", "ΎΫΰϋύὐὑὒὓὔὕὖὗὙὛὝὟὺύῠῡῢΰῦῧῨῩῪΎ"},
+	{"ω", "ΏώὠὡὢὣὤὥὦὧὨὩὪὫὬὭὮὯὼώᾠᾡᾢᾣᾤᾥᾦᾧᾨᾩᾪᾫᾬᾭᾮᾯῲῳῴῶῷῺΏῼ"},
+}
+
+// greekFolding returns the rules of a mapping char filter that strips the diacritics in greekDiacritics. The greek
+// lowercase filter alone only removes the monotonic tonos and leaves polytonic forms such as ἀ or ῆ as they are.
+func greekFolding() []string {
+	var mappings []string
+	for _, letter := range greekDiacritics {
+		for _, marked := range letter[1] {
+			mappings = append(mappings, fmt.Sprintf("%c => %s", marked, letter[0]))
+		}
+	}
+
+	return mappings
+}
+
+// CompletionIndex maps field as a completion field. Input and suggestions are stripped of their diacritics, monotonic
+// and polytonic, and lowercased with the greek lowercase filter, so unaccented input such as αρετη finds ἀρετή. Only
+// precomposed characters are folded, text in decomposed form with combining marks is left as is.
+func (b *BuilderImpl) CompletionIndex(field string) map[string]interface{} {
+	return map[string]interface{}{
+		"settings": map[string]interface{}{
+			"analysis": map[string]interface{}{
+				"analyzer": map[string]interface{}{
+					"greek_completion": map[string]interface{}{
+						"type":        "custom",
+						"char_filter": []string{"greek_diacritics"},
+						"tokenizer":   "standard",
+						"filter": []string{
+							"greek_lowercase",
+						},
+					},
+				},
+				"char_filter": map[string]interface{}{
+					"greek_diacritics": map[string]interface{}{
+						"type":     "mapping",
+						"mappings": greekFolding(),
+					},
+				},
+				"filter": map[string]interface{}{
+					"greek_lowercase": map[string]interface{}{
+						"type":     "lowercase",
+						"language": "greek",
+					},
+				},
+			},
+		},
+		"mappings": map[string]interface{}{
+			"properties": map[string]interface{}{
+				field: map[string]interface{}{
+					"type":     "completion",
+					"analyzer": "greek_completion",
+				},
+			},
+		},
+	}
+}
+
 func (b *BuilderImpl) TextIndex() map[string]interface{} {
 	return map[string]interface{}{
 		"mappings": map[string]interface{}{
@@ -3,6 +3,7 @@ package aristoteles
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -117,4 +118,132 @@ func TestBuilderClient(t *testing.T) {
 		assert.Contains(t, sut, term)
 		assert.Contains(t, sut, searchWord)
 	})
+
+	t.Run("Highlight", func(t *testing.T) {
+		file := "createDocument"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		response := testClient.Builder().Highlight(testClient.Builder().MatchQuery(term, searchWord), HighlightConfig{
+			Fields:            []string{term},
+			FragmentSize:      150,
+			NumberOfFragments: 3,
+			PreTags:           []string{"<mark>"},
+			PostTags:          []string{"</mark>"},
+		})
+
+		sut := response["highlight"].(map[string]interface{})
+		assert.Contains(t, response, "query")
+		assert.Equal(t, map[string]interface{}{term: map[string]interface{}{}}, sut["fields"])
+		assert.Equal(t, 150, sut["fragment_size"])
+		assert.Equal(t, 3, sut["number_of_fragments"])
+		assert.Equal(t, []string{"<mark>"}, sut["pre_tags"])
+		assert.Equal(t, []string{"</mark>"}, sut["post_tags"])
+	})
+
+	t.Run("HighlightDefaults", func(t *testing.T) {
+		response := NewBuilderImpl().Highlight(NewBuilderImpl().MatchAll(), HighlightConfig{Fields: []string{term}})
+
+		sut := response["highlight"].(map[string]interface{})
+		assert.Equal(t, 1, len(sut))
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		response := NewBuilderImpl().Sort(NewBuilderImpl().MatchAll(),
+			SortField{Field: "chapter", Order: SortAscending, Missing: "_last"},
+			SortField{Field: "section", Order: SortDescending, Mode: "min"},
+			SortField{Field: SortByScore},
+		)
+
+		sut := response["sort"].([]interface{})
+		assert.Contains(t, response, "query")
+		assert.Equal(t, 3, len(sut))
+		assert.Equal(t, map[string]interface{}{"chapter": map[string]interface{}{"order": "asc", "missing": "_last"}}, sut[0])
+		assert.Equal(t, map[string]interface{}{"section": map[string]interface{}{"order": "desc", "mode": "min"}}, sut[1])
+		assert.Equal(t, "_score", sut[2])
+	})
+
+	t.Run("Collapse", func(t *testing.T) {
+		response := NewBuilderImpl().Collapse(NewBuilderImpl().MatchAll(), CollapseConfig{
+			Field: "lemma",
+			InnerHits: []InnerHitsConfig{
+				{Name: "entries", Size: 5, Sort: []SortField{{Field: SortByScore, Order: SortDescending}}},
+			},
+			MaxConcurrentGroupSearches: 4,
+		})
+
+		sut := response["collapse"].(map[string]interface{})
+		assert.Contains(t, response, "query")
+		assert.Equal(t, "lemma", sut["field"])
+		assert.Equal(t, 4, sut["max_concurrent_group_searches"])
+
+		innerHits := sut["inner_hits"].([]interface{})
+		assert.Equal(t, 1, len(innerHits))
+		entries := innerHits[0].(map[string]interface{})
+		assert.Equal(t, "entries", entries["name"])
+		assert.Equal(t, 5, entries["size"])
+		assert.NotContains(t, entries, "from")
+		assert.Equal(t, []interface{}{map[string]interface{}{"_score": map[string]interface{}{"order": "desc"}}}, entries["sort"])
+	})
+
+	t.Run("CollapseWithoutInnerHits", func(t *testing.T) {
+		response := NewBuilderImpl().Collapse(NewBuilderImpl().MatchAll(), CollapseConfig{Field: "lemma"})
+
+		sut := response["collapse"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"field": "lemma"}, sut)
+	})
+
+	t.Run("Suggesters", func(t *testing.T) {
+		builder := NewBuilderImpl()
+
+		completion := builder.CompletionSuggest("lemma", "πολε", "suggest", 5)
+		assert.Equal(t, 0, completion["size"])
+		suggester := completion["suggest"].(map[string]interface{})["lemma"].(map[string]interface{})
+		assert.Equal(t, "πολε", suggester["prefix"])
+		assert.Equal(t, "suggest", suggester["completion"].(map[string]interface{})["field"])
+
+		termSuggest := builder.TermSuggest("correction", "λογος", term)
+		sut := fmt.Sprintf("%v", termSuggest)
+		assert.Contains(t, sut, "λογος")
+		assert.Contains(t, sut, "suggest_mode")
+
+		phrase := builder.PhraseSuggest("phrase", "ο λογος", "greek")
+		assert.Contains(t, phrase["suggest"].(map[string]interface{})["phrase"], "phrase")
+	})
+
+	t.Run("CompletionIndex", func(t *testing.T) {
+		response := NewBuilderImpl().CompletionIndex("suggest")
+		analysis := response["settings"].(map[string]interface{})["analysis"].(map[string]interface{})
+
+		analyzer := analysis["analyzer"].(map[string]interface{})["greek_completion"].(map[string]interface{})
+		assert.Equal(t, []string{"greek_diacritics"}, analyzer["char_filter"])
+		assert.Equal(t, "standard", analyzer["tokenizer"])
+		assert.Equal(t, []string{"greek_lowercase"}, analyzer["filter"])
+
+		charFilter := analysis["char_filter"].(map[string]interface{})["greek_diacritics"].(map[string]interface{})
+		assert.Equal(t, "mapping", charFilter["type"])
+		mappings := charFilter["mappings"].([]string)
+		assert.Contains(t, mappings, "ἀ => α")
+		assert.Contains(t, mappings, "ῥ => ρ")
+		assert.Contains(t, mappings, "ᾧ => ω")
+		assert.Contains(t, mappings, "ά => α")
+
+		field := response["mappings"].(map[string]interface{})["properties"].(map[string]interface{})["suggest"]
+		assert.Equal(t, "greek_completion", field.(map[string]interface{})["analyzer"])
+	})
+
+	t.Run("GreekFolding", func(t *testing.T) {
+		var rules []string
+		for _, mapping := range greekFolding() {
+			rule := strings.Split(mapping, " => ")
+			rules = append(rules, rule[0], rule[1])
+		}
+		fold := strings.NewReplacer(rules...)
+
+		assert.Equal(t, "αρετη", fold.Replace("ἀρετή"))
+		assert.Equal(t, "ιστοριης", fold.Replace("ἱστορίης"))
+		assert.Equal(t, "ρωμη", fold.Replace("ῥώμῃ"))
+		assert.Equal(t, "Μουσα", fold.Replace("Μοῦσα"))
+	})
 }
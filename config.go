@@ -3,10 +3,8 @@ package aristoteles
 import (
 	"fmt"
 	"github.com/odysseia-greek/aristoteles/models"
-	"io/ioutil"
-	"log"
+	"log/slog"
 	"os"
-	"path/filepath"
 	"time"
 )
 
@@ -19,6 +17,9 @@ const (
 	EnvElasticService        = "ELASTIC_SEARCH_SERVICE"
 	EnvElasticUser           = "ELASTIC_SEARCH_USER"
 	EnvElasticPassword       = "ELASTIC_SEARCH_PASSWORD"
+	EnvElasticApiKey         = "ELASTIC_SEARCH_API_KEY"
+	EnvElasticBearerToken    = "ELASTIC_SEARCH_BEARER_TOKEN"
+	EnvElasticServiceToken   = "ELASTIC_SEARCH_SERVICE_TOKEN"
 )
 
 func HealthCheck(client Client) error {
@@ -34,73 +35,98 @@ func HealthCheck(client Client) error {
 }
 
 func ElasticService(tls bool) string {
+	return ElasticServiceWithOptions(tls)
+}
+
+// ElasticServiceWithOptions is ElasticService logging the defaults it picks to the logger of WithLogger, WithQuiet
+// leaves them unlogged. Other options are ignored.
+func ElasticServiceWithOptions(tls bool, opts ...Option) string {
+	return serviceFromEnv(newSettings(opts...).configLog(), tls)
+}
+
+func serviceFromEnv(logger *slog.Logger, tls bool) string {
 	elasticService := os.Getenv(EnvElasticService)
 	if elasticService == "" {
 		if tls {
-			log.Printf("setting %s to default: %s", EnvElasticService, elasticServiceDefaultTlS)
+			logger.Info("setting env to default", slog.String("env", EnvElasticService), slog.String("default", elasticServiceDefaultTlS))
 			elasticService = elasticServiceDefaultTlS
 		} else {
-			log.Printf("setting %s to default: %s", EnvElasticService, elasticServiceDefault)
+			logger.Info("setting env to default", slog.String("env", EnvElasticService), slog.String("default", elasticServiceDefault))
 			elasticService = elasticServiceDefault
 		}
 	}
 	return elasticService
 }
 
+// ElasticConfig reads the connection details from the environment. When an api key, bearer token or service token is
+// set the username and password are only used when set explicitly, otherwise they fall back to their defaults.
 func ElasticConfig(env string, testOverwrite, tls bool) models.Config {
+	return ElasticConfigWithOptions(env, testOverwrite, tls)
+}
+
+// ElasticConfigWithOptions is ElasticConfig logging to the logger of WithLogger, or not at all with WithQuiet.
+func ElasticConfigWithOptions(env string, testOverwrite, tls bool, opts ...Option) models.Config {
+	logger := newSettings(opts...).configLog()
+
+	apiKey := os.Getenv(EnvElasticApiKey)
+	bearerToken := os.Getenv(EnvElasticBearerToken)
+	serviceToken := os.Getenv(EnvElasticServiceToken)
+	tokenAuth := apiKey != "" || bearerToken != "" || serviceToken != ""
+
 	elasticUser := os.Getenv(EnvElasticUser)
-	if elasticUser == "" {
-		log.Printf("setting %s to default: %s", EnvElasticUser, elasticUsernameDefault)
+	if elasticUser == "" && !tokenAuth {
+		logger.Info("setting env to default", slog.String("env", EnvElasticUser), slog.String("default", elasticUsernameDefault))
 		elasticUser = elasticUsernameDefault
 	}
 	elasticPassword := os.Getenv(EnvElasticPassword)
-	if elasticPassword == "" {
-		log.Printf("setting %s to default: %s", EnvElasticPassword, elasticPasswordDefault)
+	if elasticPassword == "" && !tokenAuth {
+		logger.Info("setting env to default", slog.String("env", EnvElasticPassword))
 		elasticPassword = elasticPasswordDefault
 	}
 
 	var elasticCert string
 	if tls {
-		elasticCert = string(GetCert(env, testOverwrite))
+		elasticCert = string(readCert(logger, env, testOverwrite))
 	}
 
-	elasticService := ElasticService(tls)
+	elasticService := serviceFromEnv(logger, tls)
 
 	esConf := models.Config{
-		Service:     elasticService,
-		Username:    elasticUser,
-		Password:    elasticPassword,
-		ElasticCERT: elasticCert,
+		Service:      elasticService,
+		Username:     elasticUser,
+		Password:     elasticPassword,
+		APIKey:       apiKey,
+		BearerToken:  bearerToken,
+		ServiceToken: serviceToken,
+		ElasticCERT:  elasticCert,
 	}
 
 	return esConf
 }
 
+// GetCert reads the CA certificate of the default profile matching env: local for "LOCAL", test when testOverWrite is
+// set and cluster otherwise.
+//
+// Deprecated: use LoadProfile, or DefaultProfiles when no config file is available.
 func GetCert(env string, testOverWrite bool) []byte {
-	var cert []byte
-	if env == "LOCAL" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			return nil
-		}
-		certPath := filepath.Join(homeDir, ".odysseia", "current", "elastic-certificate.pem")
-
-		cert, _ = ioutil.ReadFile(certPath)
+	return readCert(newSettings().configLog(), env, testOverWrite)
+}
 
-		return cert
+func readCert(logger *slog.Logger, env string, testOverWrite bool) []byte {
+	name := ProfileCluster
+	if env == "LOCAL" {
+		name = ProfileLocal
+	} else if testOverWrite {
+		name = ProfileTest
 	}
 
-	if testOverWrite {
-		log.Print("trying to read cert file from file")
-		certPath := filepath.Join("eratosthenes", "elastic-test-cert.pem")
-
-		cert, _ = ioutil.ReadFile(certPath)
-
-		return cert
+	profile, err := DefaultProfiles().Profile(name)
+	if err != nil {
+		return nil
 	}
 
-	log.Print("trying to read cert file from pod")
-	cert, _ = ioutil.ReadFile(certPathInPod)
+	logger.Info("trying to read cert file", slog.String("profile", name), slog.String("path", profile.TLS.CAPath))
+	cert, _ := os.ReadFile(expandHome(profile.TLS.CAPath))
 
 	return cert
 }
@@ -0,0 +1,87 @@
+package aristoteles
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+)
+
+func TestElasticConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		t.Setenv(EnvElasticService, "")
+		t.Setenv(EnvElasticUser, "")
+		t.Setenv(EnvElasticPassword, "")
+
+		sut := ElasticConfig("TEST", false, false)
+		assert.Equal(t, elasticServiceDefault, sut.Service)
+		assert.Equal(t, elasticUsernameDefault, sut.Username)
+		assert.Equal(t, elasticPasswordDefault, sut.Password)
+		assert.Equal(t, "", sut.APIKey)
+	})
+
+	t.Run("ApiKey", func(t *testing.T) {
+		apiKey := "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw=="
+		t.Setenv(EnvElasticApiKey, apiKey)
+		t.Setenv(EnvElasticUser, "")
+		t.Setenv(EnvElasticPassword, "")
+
+		sut := ElasticConfig("TEST", false, false)
+		assert.Equal(t, apiKey, sut.APIKey)
+		assert.Equal(t, "", sut.Username)
+		assert.Equal(t, "", sut.Password)
+	})
+
+	t.Run("ServiceToken", func(t *testing.T) {
+		serviceToken := "AAEAAWVsYXN0aWMvZmxlZXQtc2VydmVyL3Rva2VuMTo3TFdaSDZ"
+		t.Setenv(EnvElasticServiceToken, serviceToken)
+		t.Setenv(EnvElasticUser, "")
+		t.Setenv(EnvElasticPassword, "")
+
+		sut := ElasticConfig("TEST", false, false)
+		assert.Equal(t, serviceToken, sut.ServiceToken)
+		assert.Equal(t, "", sut.Username)
+	})
+
+	t.Run("BearerTokenWithExplicitUser", func(t *testing.T) {
+		bearerToken := "dGhpcyBpcyBub3QgYSByZWFsIHRva2VuIGJ1dCBpdCBpcyBvbmx5IHRlc3QgZGF0YS4gZG8gbm90IHRyeSB0byByZWFkIHRva2VuIQ=="
+		t.Setenv(EnvElasticBearerToken, bearerToken)
+		t.Setenv(EnvElasticUser, "sokrates")
+		t.Setenv(EnvElasticPassword, "")
+
+		sut := ElasticConfig("TEST", false, false)
+		assert.Equal(t, bearerToken, sut.BearerToken)
+		assert.Equal(t, "sokrates", sut.Username)
+		assert.Equal(t, "", sut.Password)
+	})
+
+	t.Run("InjectedLogger", func(t *testing.T) {
+		t.Setenv(EnvElasticService, "")
+		t.Setenv(EnvElasticUser, "")
+		t.Setenv(EnvElasticPassword, "")
+
+		var global bytes.Buffer
+		defer slog.SetDefault(slog.Default())
+		slog.SetDefault(slog.New(slog.NewTextHandler(&global, nil)))
+
+		logger, buf := bufferLogger()
+		sut := ElasticConfigWithOptions("TEST", true, true, WithLogger(logger))
+		assert.Equal(t, elasticServiceDefaultTlS, sut.Service)
+		assert.NotEqual(t, "", sut.ElasticCERT)
+		assert.Contains(t, buf.String(), EnvElasticUser)
+		assert.Contains(t, buf.String(), "trying to read cert file")
+		assert.Equal(t, 0, global.Len())
+	})
+
+	t.Run("Quiet", func(t *testing.T) {
+		t.Setenv(EnvElasticService, "")
+
+		var global bytes.Buffer
+		defer slog.SetDefault(slog.Default())
+		slog.SetDefault(slog.New(slog.NewTextHandler(&global, nil)))
+
+		sut := ElasticServiceWithOptions(false, WithQuiet())
+		assert.Equal(t, elasticServiceDefault, sut)
+		assert.Equal(t, 0, global.Len())
+	})
+}
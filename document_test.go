@@ -1,6 +1,7 @@
 package aristoteles
 
 import (
+	"context"
 	"github.com/odysseia-greek/aristoteles/models"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -21,6 +22,25 @@ func TestCreateDocument(t *testing.T) {
 		assert.Equal(t, index, created.Index)
 	})
 
+	t.Run("ContextCancelled", func(t *testing.T) {
+		config := models.Config{
+			Service:     "http://localhost:9200",
+			Username:    "",
+			Password:    "",
+			ElasticCERT: "",
+		}
+		testClient, err := NewClient(config)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		created, err := testClient.Document().CreateContext(ctx, index, body)
+		assert.NotNil(t, err)
+		assert.Nil(t, created)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		file := "createIndex"
 		status := 502
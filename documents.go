@@ -8,11 +8,11 @@ import (
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/odysseia-greek/aristoteles/models"
 	"io/ioutil"
-	"log"
 )
 
 type DocumentImpl struct {
-	es *elasticsearch.Client
+	es         *elasticsearch.Client
+	instrument *instrument
 }
 
 func NewDocumentImpl(suppliedClient *elasticsearch.Client) (*DocumentImpl, error) {
@@ -20,13 +20,20 @@ func NewDocumentImpl(suppliedClient *elasticsearch.Client) (*DocumentImpl, error
 }
 
 func (d *DocumentImpl) Create(index string, body []byte) (*models.CreateResult, error) {
+	return d.CreateContext(context.Background(), index, body)
+}
+
+func (d *DocumentImpl) CreateContext(ctx context.Context, index string, body []byte) (result *models.CreateResult, err error) {
+	ctx, op := d.instrument.begin(ctx, "create", index)
+	defer func() { op.end(err) }()
+
 	var elasticResult models.CreateResult
 
-	ctx := context.Background()
 	res, err := esapi.CreateRequest{
 		Index: index,
 		Body:  bytes.NewReader(body),
 	}.Do(ctx, d.es)
+	op.response(res)
 
 	if err != nil {
 		return nil, err
@@ -35,7 +42,7 @@ func (d *DocumentImpl) Create(index string, body []byte) (*models.CreateResult,
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return nil, newElasticError(res)
 	}
 
 	jsonBody, _ := ioutil.ReadAll(res.Body)
@@ -48,14 +55,21 @@ func (d *DocumentImpl) Create(index string, body []byte) (*models.CreateResult,
 }
 
 func (d *DocumentImpl) Update(index, id string, body []byte) (*models.CreateResult, error) {
+	return d.UpdateContext(context.Background(), index, id, body)
+}
+
+func (d *DocumentImpl) UpdateContext(ctx context.Context, index, id string, body []byte) (result *models.CreateResult, err error) {
+	ctx, op := d.instrument.begin(ctx, "update", index)
+	defer func() { op.end(err) }()
+
 	var elasticResult models.CreateResult
 
-	ctx := context.Background()
 	res, err := esapi.UpdateRequest{
 		Index:      index,
 		DocumentID: id,
 		Body:       bytes.NewReader([]byte(fmt.Sprintf(`{"doc":%s}`, body))),
 	}.Do(ctx, d.es)
+	op.response(res)
 
 	if err != nil {
 		return nil, err
@@ -64,9 +78,7 @@ func (d *DocumentImpl) Update(index, id string, body []byte) (*models.CreateResu
 	defer res.Body.Close()
 
 	if res.IsError() {
-		jsonBody, _ := ioutil.ReadAll(res.Body)
-		log.Print(jsonBody)
-		return nil, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return nil, newElasticError(res)
 	}
 
 	jsonBody, _ := ioutil.ReadAll(res.Body)
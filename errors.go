@@ -0,0 +1,123 @@
+package aristoteles
+
+import (
+	"errors"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/odysseia-greek/aristoteles/models"
+	"io"
+	"net/http"
+)
+
+// ElasticError is returned for every response elasticsearch answers with a non 2xx status code.
+type ElasticError struct {
+	StatusCode    int
+	Status        string
+	Type          string
+	Reason        string
+	Index         string
+	RootCauses    []models.ErrorCause
+	ShardFailures []models.ShardFailure
+}
+
+func (e *ElasticError) Error() string {
+	if e.Type == "" && e.Reason == "" {
+		return fmt.Sprintf("%s: %s", errorMessage, e.Status)
+	}
+
+	if e.Type == "" {
+		return fmt.Sprintf("%s: %s: %s", errorMessage, e.Status, e.Reason)
+	}
+
+	return fmt.Sprintf("%s: %s: %s: %s", errorMessage, e.Status, e.Type, e.Reason)
+}
+
+// newElasticError reads the body of a failed response into an ElasticError, the body is not closed.
+func newElasticError(res *esapi.Response) error {
+	elasticError := &ElasticError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status(),
+	}
+
+	if res.Body == nil {
+		return elasticError
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil || len(body) == 0 {
+		return elasticError
+	}
+
+	indexError, err := models.UnmarshalIndexError(body)
+	if err != nil {
+		return elasticError
+	}
+
+	elasticError.setCause(indexError.Error)
+
+	return elasticError
+}
+
+// setCause copies the error elasticsearch returned, the index falls back to the first root cause that names one.
+func (e *ElasticError) setCause(cause models.ErrorCause) {
+	e.Type = cause.Type
+	e.Reason = cause.Reason
+	e.Index = cause.Index
+	e.RootCauses = cause.RootCause
+	e.ShardFailures = cause.FailedShards
+
+	if e.Index == "" {
+		for _, rootCause := range e.RootCauses {
+			if rootCause.Index != "" {
+				e.Index = rootCause.Index
+				break
+			}
+		}
+	}
+}
+
+// IsNotFound reports whether err is an ElasticError for a missing index or document.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an ElasticError for a version conflict or an already existing document.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsUnauthorized reports whether err is an ElasticError for missing or invalid credentials.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an ElasticError for credentials lacking the required privileges.
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsRetryable reports whether err is an ElasticError that might succeed when the request is sent again.
+func IsRetryable(err error) bool {
+	return hasStatus(err,
+		http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	)
+}
+
+func hasStatus(err error, statusCodes ...int) bool {
+	var elasticError *ElasticError
+	if !errors.As(err, &elasticError) {
+		return false
+	}
+
+	for _, statusCode := range statusCodes {
+		if elasticError.StatusCode == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,129 @@
+package aristoteles
+
+import (
+	"errors"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestElasticError(t *testing.T) {
+	index := "test"
+	match := "elastic"
+	word := "isGreat"
+
+	t.Run("NotFound", func(t *testing.T) {
+		file := "deleteIndex404"
+		status := 404
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Index().Delete(index)
+		assert.False(t, sut)
+
+		var elasticError *ElasticError
+		assert.True(t, errors.As(err, &elasticError))
+		assert.True(t, IsNotFound(err))
+		assert.False(t, IsRetryable(err))
+		assert.Equal(t, 404, elasticError.StatusCode)
+		assert.Equal(t, "index_not_found_exception", elasticError.Type)
+		assert.Equal(t, "no such index [grammar]", elasticError.Reason)
+		assert.Equal(t, "grammar", elasticError.Index)
+		assert.Equal(t, 1, len(elasticError.RootCauses))
+		assert.Equal(t, "index_or_alias", elasticError.RootCauses[0].ResourceType)
+	})
+
+	t.Run("ShardFailure", func(t *testing.T) {
+		file := "shardFailure"
+		status := 500
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+		sut, err := testClient.Query().Match(index, body)
+		assert.Nil(t, sut)
+
+		var elasticError *ElasticError
+		assert.True(t, errors.As(err, &elasticError))
+		assert.Equal(t, "search_phase_execution_exception", elasticError.Type)
+		assert.Equal(t, 1, len(elasticError.ShardFailures))
+		assert.Equal(t, "illegal_state_exception", elasticError.ShardFailures[0].Reason.Type)
+		assert.Contains(t, err.Error(), errorMessage)
+		assert.Contains(t, err.Error(), "all shards failed")
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		file := "versionConflict"
+		status := 409
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		created, err := testClient.Document().Create(index, []byte(`{"greek":"μάχη"}`))
+		assert.Nil(t, created)
+		assert.True(t, IsConflict(err))
+		assert.False(t, IsNotFound(err))
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		file := "unauthorized"
+		status := 401
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+		sut, err := testClient.Query().Match(index, body)
+		assert.Nil(t, sut)
+		assert.True(t, IsUnauthorized(err))
+		assert.False(t, IsForbidden(err))
+	})
+
+	t.Run("Retryable", func(t *testing.T) {
+		file := "tooManyRequests"
+		status := 429
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+		sut, err := testClient.Query().Match(index, body)
+		assert.Nil(t, sut)
+		assert.True(t, IsRetryable(err))
+		assert.Contains(t, err.Error(), "es_rejected_execution_exception")
+	})
+
+	t.Run("ProxyError", func(t *testing.T) {
+		res := &esapi.Response{
+			StatusCode: 502,
+			Body:       fixture("serviceDown.json"),
+		}
+
+		err := newElasticError(res)
+
+		var elasticError *ElasticError
+		assert.True(t, errors.As(err, &elasticError))
+		assert.True(t, IsRetryable(err))
+		assert.Equal(t, "Bad Gateway", elasticError.Reason)
+	})
+
+	t.Run("UnparseableBody", func(t *testing.T) {
+		file := "error"
+		status := 500
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+		sut, err := testClient.Query().Match(index, body)
+		assert.Nil(t, sut)
+
+		var elasticError *ElasticError
+		assert.True(t, errors.As(err, &elasticError))
+		assert.Equal(t, 500, elasticError.StatusCode)
+		assert.Equal(t, "", elasticError.Type)
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		err := fmt.Errorf("searching dictionary: %w", &ElasticError{StatusCode: 503})
+		assert.True(t, IsRetryable(err))
+		assert.False(t, IsNotFound(fmt.Errorf("plain error")))
+	})
+}
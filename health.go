@@ -1,16 +1,19 @@
 package aristoteles
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/odysseia-greek/aristoteles/models"
-	"log"
+	"log/slog"
 	"time"
 )
 
 type HealthImpl struct {
-	es *elasticsearch.Client
+	es         *elasticsearch.Client
+	instrument *instrument
+	circuit    *circuitBreaker
 }
 
 func NewHealthImpl(suppliedClient *elasticsearch.Client) (*HealthImpl, error) {
@@ -18,6 +21,10 @@ func NewHealthImpl(suppliedClient *elasticsearch.Client) (*HealthImpl, error) {
 }
 
 func (h *HealthImpl) Check(ticks, tick time.Duration) bool {
+	return h.CheckContext(context.Background(), ticks, tick)
+}
+
+func (h *HealthImpl) CheckContext(ctx context.Context, ticks, tick time.Duration) bool {
 	healthy := false
 
 	ticker := time.NewTicker(tick)
@@ -26,11 +33,11 @@ func (h *HealthImpl) Check(ticks, tick time.Duration) bool {
 	for {
 		select {
 		case t := <-ticker.C:
-			log.Printf("tick: %s", t)
-			res := h.Info()
+			h.instrument.tick("tick", slog.Time("time", t))
+			res := h.info(ctx, true)
 			healthy = res.Healthy
 			if !healthy {
-				log.Print("Elastic not yet healthy")
+				h.instrument.tick("elastic not yet healthy")
 				continue
 			}
 
@@ -38,6 +45,9 @@ func (h *HealthImpl) Check(ticks, tick time.Duration) bool {
 
 		case <-timeout:
 			ticker.Stop()
+
+		case <-ctx.Done():
+			ticker.Stop()
 		}
 		break
 	}
@@ -46,7 +56,26 @@ func (h *HealthImpl) Check(ticks, tick time.Duration) bool {
 }
 
 func (h *HealthImpl) Info() (elasticHealth models.DatabaseHealth) {
-	res, err := h.es.Info()
+	return h.InfoContext(context.Background())
+}
+
+func (h *HealthImpl) InfoContext(ctx context.Context) (elasticHealth models.DatabaseHealth) {
+	return h.info(ctx, false)
+}
+
+// info asks the cluster for its info, a probe is a tick of CheckContext and fails quietly.
+func (h *HealthImpl) info(ctx context.Context, probe bool) (elasticHealth models.DatabaseHealth) {
+	var err error
+	ctx, op := h.instrument.begin(ctx, "info", "")
+	op.probe = probe
+	defer func() { op.end(err) }()
+
+	if h.circuit != nil {
+		elasticHealth.Circuit = h.circuit.State().String()
+	}
+
+	res, err := h.es.Info(h.es.Info.WithContext(ctx))
+	op.response(res)
 
 	if err != nil {
 		elasticHealth.Healthy = false
@@ -55,6 +84,7 @@ func (h *HealthImpl) Info() (elasticHealth models.DatabaseHealth) {
 	defer res.Body.Close()
 	// Check response status
 	if res.IsError() {
+		err = newElasticError(res)
 		elasticHealth.Healthy = false
 		return elasticHealth
 	}
@@ -62,7 +92,7 @@ func (h *HealthImpl) Info() (elasticHealth models.DatabaseHealth) {
 	var r map[string]interface{}
 
 	// Deserialize the response into a map.
-	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+	if err = json.NewDecoder(res.Body).Decode(&r); err != nil {
 		elasticHealth.Healthy = false
 		return elasticHealth
 	}
@@ -1,6 +1,7 @@
 package aristoteles
 
 import (
+	"context"
 	"github.com/odysseia-greek/aristoteles/models"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -21,6 +22,19 @@ func TestHealthClient(t *testing.T) {
 		assert.True(t, healthy)
 	})
 
+	t.Run("ContextCancelled", func(t *testing.T) {
+		file := "info"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		healthy := testClient.Health().CheckContext(ctx, time.Minute, time.Minute)
+		assert.False(t, healthy)
+	})
+
 	t.Run("Unhealthy", func(t *testing.T) {
 		file := "infoServiceDown"
 		status := 502
@@ -56,4 +70,31 @@ func TestHealthClient(t *testing.T) {
 
 		assert.False(t, healthy)
 	})
+
+	t.Run("QuietWhileDown", func(t *testing.T) {
+		logger, buf := bufferLogger()
+		config := models.Config{
+			Service: "http://127.0.0.1:1",
+		}
+		testClient, err := NewClient(config, WithLogger(logger), WithQuiet(), WithMaxRetries(0))
+		assert.Nil(t, err)
+
+		healthy := testClient.Health().Check(5*tick, tick)
+		assert.False(t, healthy)
+		assert.Equal(t, 0, buf.Len())
+	})
+
+	t.Run("ProbeFailuresAtDebug", func(t *testing.T) {
+		logger, buf := bufferLogger()
+		config := models.Config{
+			Service: "http://127.0.0.1:1",
+		}
+		testClient, err := NewClient(config, WithLogger(logger), WithMaxRetries(0))
+		assert.Nil(t, err)
+
+		healthy := testClient.Health().Check(5*tick, tick)
+		assert.False(t, healthy)
+		assert.Contains(t, buf.String(), "elastic not yet healthy")
+		assert.NotContains(t, buf.String(), `"level":"WARN"`)
+	})
 }
@@ -1,11 +1,10 @@
 package aristoteles
 
 import (
-	"crypto/x509"
+	"context"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/odysseia-greek/aristoteles/models"
-	"log"
-	"net/http"
+	"log/slog"
 	"strings"
 	"time"
 )
@@ -17,24 +16,57 @@ type Client interface {
 	Builder() Builder
 	Health() Health
 	Access() Access
+	Template() Template
 }
 
 type Query interface {
 	Match(index string, request map[string]interface{}) (*models.Response, error)
+	MatchContext(ctx context.Context, index string, request map[string]interface{}) (*models.Response, error)
+	MatchWithOptions(index string, request map[string]interface{}, opts ...SearchOption) (*models.Response, error)
+	MatchWithOptionsContext(ctx context.Context, index string, request map[string]interface{}, opts ...SearchOption) (*models.Response, error)
+	MatchPage(index string, request map[string]interface{}, page, size int, opts ...SearchOption) (*models.Page[models.Hit], error)
+	MatchPageContext(ctx context.Context, index string, request map[string]interface{}, page, size int, opts ...SearchOption) (*models.Page[models.Hit], error)
 	MatchWithSort(index, mode, sort string, size int, request map[string]interface{}) (*models.Response, error)
+	MatchWithSortContext(ctx context.Context, index, mode, sort string, size int, request map[string]interface{}) (*models.Response, error)
+	// Deprecated: use Iterate, MatchWithScroll keeps every hit in memory.
 	MatchWithScroll(index string, request map[string]interface{}) (*models.Response, error)
+	// Deprecated: use Iterate.
+	MatchWithScrollContext(ctx context.Context, index string, request map[string]interface{}) (*models.Response, error)
 	MatchAggregate(index string, request map[string]interface{}) (*models.Aggregations, error)
+	MatchAggregateContext(ctx context.Context, index string, request map[string]interface{}) (*models.Aggregations, error)
+	AsyncSearch(index string, request map[string]interface{}, config AsyncSearchConfig) (*models.AsyncSearchResponse, error)
+	AsyncSearchContext(ctx context.Context, index string, request map[string]interface{}, config AsyncSearchConfig) (*models.AsyncSearchResponse, error)
+	AsyncSearchStatus(id string) (*models.AsyncSearchStatus, error)
+	AsyncSearchStatusContext(ctx context.Context, id string) (*models.AsyncSearchStatus, error)
+	AsyncSearchResult(id string) (*models.AsyncSearchResponse, error)
+	AsyncSearchResultContext(ctx context.Context, id string) (*models.AsyncSearchResponse, error)
+	WaitAsyncSearch(id string, poll time.Duration) (*models.AsyncSearchResponse, error)
+	WaitAsyncSearchContext(ctx context.Context, id string, poll time.Duration) (*models.AsyncSearchResponse, error)
+	DeleteAsyncSearch(id string) error
+	DeleteAsyncSearchContext(ctx context.Context, id string) error
+	Iterate(ctx context.Context, index string, request map[string]interface{}, config IteratorConfig) *Iterator
+	MultiSearch(requests []SearchRequest) ([]MultiSearchResult, error)
+	MultiSearchContext(ctx context.Context, requests []SearchRequest) ([]MultiSearchResult, error)
+	Count(index string, request map[string]interface{}) (int64, error)
+	CountContext(ctx context.Context, index string, request map[string]interface{}) (int64, error)
+	Suggest(index string, request map[string]interface{}) (map[string][]models.Suggestion, error)
+	SuggestContext(ctx context.Context, index string, request map[string]interface{}) (map[string][]models.Suggestion, error)
 }
 
 type Document interface {
 	Create(index string, body []byte) (*models.CreateResult, error)
+	CreateContext(ctx context.Context, index string, body []byte) (*models.CreateResult, error)
 	Update(index, id string, body []byte) (*models.CreateResult, error)
+	UpdateContext(ctx context.Context, index, id string, body []byte) (*models.CreateResult, error)
 }
 
 type Index interface {
 	CreateDocument(index string, body []byte) (*models.CreateResult, error)
+	CreateDocumentContext(ctx context.Context, index string, body []byte) (*models.CreateResult, error)
 	Create(index string, request map[string]interface{}) (*models.IndexCreateResult, error)
+	CreateContext(ctx context.Context, index string, request map[string]interface{}) (*models.IndexCreateResult, error)
 	Delete(index string) (bool, error)
+	DeleteContext(ctx context.Context, index string) (bool, error)
 }
 
 type Builder interface {
@@ -45,9 +77,16 @@ type Builder interface {
 	MatchPhrasePrefixed(queryWord, field string) map[string]interface{}
 	Aggregate(aggregate, field string) map[string]interface{}
 	FilteredAggregate(term, queryWord, aggregate, field string) map[string]interface{}
+	Sort(request map[string]interface{}, fields ...SortField) map[string]interface{}
+	Highlight(request map[string]interface{}, config HighlightConfig) map[string]interface{}
+	Collapse(request map[string]interface{}, config CollapseConfig) map[string]interface{}
+	CompletionSuggest(name, prefix, field string, size int) map[string]interface{}
+	TermSuggest(name, text, field string) map[string]interface{}
+	PhraseSuggest(name, text, field string) map[string]interface{}
 	SearchAsYouTypeIndex(searchWord string) map[string]interface{}
 	Index() map[string]interface{}
 	TextIndex() map[string]interface{}
+	CompletionIndex(field string) map[string]interface{}
 	DictionaryIndex(min, max int) map[string]interface{}
 	GrammarIndex() map[string]interface{}
 	QuizIndex() map[string]interface{}
@@ -55,12 +94,29 @@ type Builder interface {
 
 type Health interface {
 	Check(ticks, tick time.Duration) bool
+	CheckContext(ctx context.Context, ticks, tick time.Duration) bool
 	Info() (elasticHealth models.DatabaseHealth)
+	InfoContext(ctx context.Context) (elasticHealth models.DatabaseHealth)
 }
 
 type Access interface {
 	CreateRole(name string, roleRequest models.CreateRoleRequest) (bool, error)
+	CreateRoleContext(ctx context.Context, name string, roleRequest models.CreateRoleRequest) (bool, error)
 	CreateUser(name string, userCreation models.CreateUserRequest) (bool, error)
+	CreateUserContext(ctx context.Context, name string, userCreation models.CreateUserRequest) (bool, error)
+}
+
+type Template interface {
+	Put(id, source string) (bool, error)
+	PutContext(ctx context.Context, id, source string) (bool, error)
+	List() ([]models.StoredTemplate, error)
+	ListContext(ctx context.Context) ([]models.StoredTemplate, error)
+	Delete(id string) (bool, error)
+	DeleteContext(ctx context.Context, id string) (bool, error)
+	Search(index, id string, params interface{}) (*models.Response, error)
+	SearchContext(ctx context.Context, index, id string, params interface{}) (*models.Response, error)
+	Render(id string, params interface{}) (map[string]interface{}, error)
+	RenderContext(ctx context.Context, id string, params interface{}) (map[string]interface{}, error)
 }
 
 type Elastic struct {
@@ -70,149 +126,134 @@ type Elastic struct {
 	builder  *BuilderImpl
 	health   *HealthImpl
 	access   *AccessImpl
+	template *TemplateImpl
 }
 
-func NewClient(config models.Config) (Client, error) {
-	//https://patorjk.com/software/taag/#p=display&f=Crawford2&t=ARISTOTELES
-	log.Print("\n  ____  ____   ____ _____ ______   ___   ______    ___  _        ___  _____\n /    ||    \\ |    / ___/|      | /   \\ |      |  /  _]| |      /  _]/ ___/\n|  o  ||  D  ) |  (   \\_ |      ||     ||      | /  [_ | |     /  [_(   \\_ \n|     ||    /  |  |\\__  ||_|  |_||  O  ||_|  |_||    _]| |___ |    _]\\__  |\n|  _  ||    \\  |  |/  \\ |  |  |  |     |  |  |  |   [_ |     ||   [_ /  \\ |\n|  |  ||  .  \\ |  |\\    |  |  |  |     |  |  |  |     ||     ||     |\\    |\n|__|__||__|\\_||____|\\___|  |__|   \\___/   |__|  |_____||_____||_____| \\___|\n                                                                           \n")
-	log.Print(strings.Repeat("~", 37))
-	log.Print("\"Τριών δει παιδεία: φύσεως, μαθήσεως, ασκήσεως.\"")
-	log.Print("\"Education needs these three: natural endowment, study, practice.\"")
-	log.Print(strings.Repeat("~", 37))
+func NewClient(config models.Config, opts ...Option) (Client, error) {
+	settings := newSettings(opts...)
+
+	if !settings.quiet {
+		banner(settings.log())
+	}
 
 	var err error
 	var esClient *elasticsearch.Client
-	if config.ElasticCERT != "" {
-		esClient, err = createWithTLS(config)
+	if settings.reload != nil {
+		esClient, err = createWithReload(config, settings)
+		if err != nil {
+			return nil, err
+		}
+	} else if usesTLS(config, settings) {
+		esClient, err = createWithTLS(config, settings)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		esClient, err = create(config)
+		esClient, err = create(config, settings)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	query, err := NewQueryImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
-
-	index, err := NewIndexImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
-
-	health, err := NewHealthImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
-
-	access, err := NewAccessImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
+	return newElastic(esClient, settings)
+}
 
-	document, err := NewDocumentImpl(esClient)
+func NewMockClient(fixtureFile string, statusCode int, opts ...Option) (Client, error) {
+	esClient, err := CreateMockClient(fixtureFile, statusCode)
 	if err != nil {
 		return nil, err
 	}
 
-	builder := NewBuilderImpl()
-
-	es := &Elastic{query: query, index: index, builder: builder, health: health, access: access, document: document}
-
-	return es, nil
+	return newElastic(esClient, newSettings(opts...))
 }
 
-func NewMockClient(fixtureFile string, statusCode int) (Client, error) {
-	esClient, err := CreateMockClient(fixtureFile, statusCode)
-	if err != nil {
-		return nil, err
-	}
+func newElastic(esClient *elasticsearch.Client, settings *settings) (*Elastic, error) {
+	instrument := newInstrument(settings)
 
 	query, err := NewQueryImpl(esClient)
 	if err != nil {
 		return nil, err
 	}
+	query.instrument = instrument
 
 	index, err := NewIndexImpl(esClient)
 	if err != nil {
 		return nil, err
 	}
+	index.instrument = instrument
 
 	health, err := NewHealthImpl(esClient)
 	if err != nil {
 		return nil, err
 	}
+	health.instrument = instrument
+	health.circuit = settings.circuit
 
 	access, err := NewAccessImpl(esClient)
 	if err != nil {
 		return nil, err
 	}
+	access.instrument = instrument
 
 	document, err := NewDocumentImpl(esClient)
 	if err != nil {
 		return nil, err
 	}
+	document.instrument = instrument
+
+	template, err := NewTemplateImpl(esClient)
+	if err != nil {
+		return nil, err
+	}
+	template.instrument = instrument
 
 	builder := NewBuilderImpl()
 
-	es := &Elastic{query: query, index: index, builder: builder, health: health, access: access, document: document}
+	es := &Elastic{query: query, index: index, builder: builder, health: health, access: access, document: document, template: template}
 
 	return es, nil
 }
 
-func create(config models.Config) (*elasticsearch.Client, error) {
-	log.Print("creating elasticClient")
+func banner(logger *slog.Logger) {
+	//https://patorjk.com/software/taag/#p=display&f=Crawford2&t=ARISTOTELES
+	logger.Info("\n  ____  ____   ____ _____ ______   ___   ______    ___  _        ___  _____\n /    ||    \\ |    / ___/|      | /   \\ |      |  /  _]| |      /  _]/ ___/\n|  o  ||  D  ) |  (   \\_ |      ||     ||      | /  [_ | |     /  [_(   \\_ \n|     ||    /  |  |\\__  ||_|  |_||  O  ||_|  |_||    _]| |___ |    _]\\__  |\n|  _  ||    \\  |  |/  \\ |  |  |  |     |  |  |  |   [_ |     ||   [_ /  \\ |\n|  |  ||  .  \\ |  |\\    |  |  |  |     |  |  |  |     ||     ||     |\\    |\n|__|__||__|\\_||____|\\___|  |__|   \\___/   |__|  |_____||_____||_____| \\___|\n                                                                           \n")
+	logger.Info(strings.Repeat("~", 37))
+	logger.Info("\"Τριών δει παιδεία: φύσεως, μαθήσεως, ασκήσεως.\"")
+	logger.Info("\"Education needs these three: natural endowment, study, practice.\"")
+	logger.Info(strings.Repeat("~", 37))
+}
 
-	cfg := elasticsearch.Config{
-		Username:  config.Username,
-		Password:  config.Password,
-		Addresses: []string{config.Service},
-	}
+func create(config models.Config, settings *settings) (*elasticsearch.Client, error) {
+	settings.log().Info("creating elasticClient")
+
+	cfg := settings.elasticConfig(config)
 	es, err := elasticsearch.NewClient(cfg)
 	if err != nil {
-		log.Printf("Error creating the client: %s", err)
+		settings.log().Error("error creating the client", slog.String("error", err.Error()))
 		return nil, err
 	}
 
 	return es, nil
 }
 
-func createWithTLS(config models.Config) (*elasticsearch.Client, error) {
-	log.Print("creating elasticClient with tls")
+func createWithTLS(config models.Config, settings *settings) (*elasticsearch.Client, error) {
+	settings.log().Info("creating elasticClient with tls")
 
-	caCert := []byte(config.ElasticCERT)
-
-	// --> Clone the default HTTP transport
-
-	tp := http.DefaultTransport.(*http.Transport).Clone()
-
-	// --> Initialize the set of root certificate authorities
-	//
-	var err error
-
-	if tp.TLSClientConfig.RootCAs, err = x509.SystemCertPool(); err != nil {
-		log.Fatalf("ERROR: Problem adding system CA: %s", err)
+	tp, err := settings.httpTransport()
+	if err != nil {
+		return nil, err
 	}
 
-	// --> Add the custom certificate authority
-	//
-	if ok := tp.TLSClientConfig.RootCAs.AppendCertsFromPEM(caCert); !ok {
-		log.Fatalf("ERROR: Problem adding CA from file %q", caCert)
+	tp.TLSClientConfig, err = newTLSConfig(tp.TLSClientConfig, config, settings)
+	if err != nil {
+		return nil, err
 	}
 
-	cfg := elasticsearch.Config{
-		Username:  config.Username,
-		Password:  config.Password,
-		Addresses: []string{config.Service},
-		Transport: tp,
-	}
+	cfg := settings.elasticConfig(config)
+	cfg.Transport = settings.wrapTransport(tp)
 	es, err := elasticsearch.NewClient(cfg)
 	if err != nil {
-		log.Printf("Error creating the client: %s", err)
+		settings.log().Error("error creating the client", slog.String("error", err.Error()))
 		return nil, err
 	}
 
@@ -260,3 +301,10 @@ func (e *Elastic) Access() Access {
 	}
 	return e.access
 }
+
+func (e *Elastic) Template() Template {
+	if e == nil {
+		return nil
+	}
+	return e.template
+}
@@ -3,17 +3,17 @@ package aristoteles
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/odysseia-greek/aristoteles/models"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"strings"
 )
 
 type IndexImpl struct {
-	es *elasticsearch.Client
+	es         *elasticsearch.Client
+	instrument *instrument
 }
 
 func NewIndexImpl(suppliedClient *elasticsearch.Client) (*IndexImpl, error) {
@@ -21,6 +21,13 @@ func NewIndexImpl(suppliedClient *elasticsearch.Client) (*IndexImpl, error) {
 }
 
 func (i *IndexImpl) CreateDocument(index string, body []byte) (*models.CreateResult, error) {
+	return i.CreateDocumentContext(context.Background(), index, body)
+}
+
+func (i *IndexImpl) CreateDocumentContext(ctx context.Context, index string, body []byte) (result *models.CreateResult, err error) {
+	ctx, op := i.instrument.begin(ctx, "index", index)
+	defer func() { op.end(err) }()
+
 	var elasticResult models.CreateResult
 	bodyString := strings.NewReader(string(body))
 
@@ -31,7 +38,8 @@ func (i *IndexImpl) CreateDocument(index string, body []byte) (*models.CreateRes
 		DocumentID: "",
 	}
 
-	res, err := esRequest.Do(context.Background(), i.es)
+	res, err := esRequest.Do(ctx, i.es)
+	op.response(res)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +47,7 @@ func (i *IndexImpl) CreateDocument(index string, body []byte) (*models.CreateRes
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return nil, newElasticError(res)
 	}
 
 	jsonBody, _ := ioutil.ReadAll(res.Body)
@@ -52,6 +60,13 @@ func (i *IndexImpl) CreateDocument(index string, body []byte) (*models.CreateRes
 }
 
 func (i *IndexImpl) Create(index string, request map[string]interface{}) (*models.IndexCreateResult, error) {
+	return i.CreateContext(context.Background(), index, request)
+}
+
+func (i *IndexImpl) CreateContext(ctx context.Context, index string, request map[string]interface{}) (result *models.IndexCreateResult, err error) {
+	ctx, op := i.instrument.begin(ctx, "create_index", index)
+	defer func() { op.end(err) }()
+
 	query, err := toBuffer(request)
 	if err != nil {
 		return nil, err
@@ -63,14 +78,15 @@ func (i *IndexImpl) Create(index string, request map[string]interface{}) (*model
 		Body:  &query,
 	}
 
-	res, err := indexRequest.Do(context.Background(), i.es)
+	res, err := indexRequest.Do(ctx, i.es)
+	op.response(res)
 	if err != nil {
 		return &elasticResult, err
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return nil, newElasticError(res)
 	}
 
 	jsonBody, _ := ioutil.ReadAll(res.Body)
@@ -83,6 +99,13 @@ func (i *IndexImpl) Create(index string, request map[string]interface{}) (*model
 }
 
 func (i *IndexImpl) Update(index string, request map[string]interface{}) (*models.IndexCreateResult, error) {
+	return i.UpdateContext(context.Background(), index, request)
+}
+
+func (i *IndexImpl) UpdateContext(ctx context.Context, index string, request map[string]interface{}) (result *models.IndexCreateResult, err error) {
+	ctx, op := i.instrument.begin(ctx, "update_index", index)
+	defer func() { op.end(err) }()
+
 	query, err := toBuffer(request)
 	if err != nil {
 		return nil, err
@@ -94,14 +117,15 @@ func (i *IndexImpl) Update(index string, request map[string]interface{}) (*model
 		Body:  &query,
 	}
 
-	res, err := indexRequest.Do(context.Background(), i.es)
+	res, err := indexRequest.Do(ctx, i.es)
+	op.response(res)
 	if err != nil {
 		return &elasticResult, err
 	}
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return nil, newElasticError(res)
 	}
 
 	jsonBody, _ := ioutil.ReadAll(res.Body)
@@ -114,22 +138,30 @@ func (i *IndexImpl) Update(index string, request map[string]interface{}) (*model
 }
 
 func (i *IndexImpl) Delete(index string) (bool, error) {
-	log.Printf("deleting index: %s", index)
+	return i.DeleteContext(context.Background(), index)
+}
 
-	res, err := i.es.Indices.Delete([]string{index})
+func (i *IndexImpl) DeleteContext(ctx context.Context, index string) (deleted bool, err error) {
+	ctx, op := i.instrument.begin(ctx, "delete_index", index)
+	defer func() { op.end(err) }()
+
+	i.instrument.log().Info("deleting index", slog.String("index", index))
+
+	res, err := i.es.Indices.Delete([]string{index}, i.es.Indices.Delete.WithContext(ctx))
+	op.response(res)
 	if err != nil {
 		return false, err
 	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return false, newElasticError(res)
+	}
 
 	var r map[string]interface{}
 	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
 		return false, err
 	}
 
-	if res.IsError() {
-		l, _ := json.Marshal(r)
-		return false, fmt.Errorf(string(l))
-	}
-
 	return r["acknowledged"].(bool), nil
 }
@@ -1,6 +1,7 @@
 package aristoteles
 
 import (
+	"context"
 	"github.com/odysseia-greek/aristoteles/models"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -151,6 +152,25 @@ func TestDeleteIndexClient(t *testing.T) {
 		assert.True(t, sut)
 	})
 
+	t.Run("ContextCancelled", func(t *testing.T) {
+		config := models.Config{
+			Service:     "http://localhost:9200",
+			Username:    "",
+			Password:    "",
+			ElasticCERT: "",
+		}
+		testClient, err := NewClient(config)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		sut, err := testClient.Index().DeleteContext(ctx, index)
+		assert.NotNil(t, err)
+		assert.False(t, sut)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		file := "deleteIndex"
 		status := 502
@@ -0,0 +1,170 @@
+package aristoteles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
+)
+
+const instrumentationName = "github.com/odysseia-greek/aristoteles"
+
+// instrument is shared by all implementations of a client and observes every call made to elasticsearch.
+// A nil instrument is valid and falls back to the default logger.
+type instrument struct {
+	logger  *slog.Logger
+	quiet   bool
+	tracer  trace.Tracer
+	metrics *Metrics
+}
+
+func newInstrument(settings *settings) *instrument {
+	i := &instrument{
+		logger:  settings.log(),
+		quiet:   settings.quiet,
+		metrics: settings.metrics,
+	}
+
+	if settings.tracerProvider != nil {
+		i.tracer = settings.tracerProvider.Tracer(instrumentationName)
+	}
+
+	return i
+}
+
+func (i *instrument) log() *slog.Logger {
+	if i == nil || i.logger == nil {
+		return slog.Default()
+	}
+
+	return i.logger
+}
+
+// tick logs progress of long-running loops such as the health check, unless the client is quiet.
+func (i *instrument) tick(msg string, args ...any) {
+	if i != nil && i.quiet {
+		return
+	}
+
+	i.log().Debug(msg, args...)
+}
+
+// operation is a single call to elasticsearch, started with begin and finished with end.
+type operation struct {
+	instrument *instrument
+	name       string
+	index      string
+	start      time.Time
+	status     int
+	span       trace.Span
+	elapsed    time.Duration
+	searched   bool
+	returned   int
+	pages      int
+	// probe marks calls polled in a loop such as the health check, failing is expected while the cluster starts so it
+	// is logged like a tick.
+	probe bool
+}
+
+// begin starts an operation, the returned context carries its span and has to be used for the request.
+func (i *instrument) begin(ctx context.Context, name, index string) (context.Context, *operation) {
+	op := &operation{
+		instrument: i,
+		name:       name,
+		index:      index,
+		start:      time.Now(),
+	}
+
+	if i != nil && i.tracer != nil {
+		ctx, op.span = i.tracer.Start(ctx, fmt.Sprintf("elasticsearch %s", name),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemElasticsearch,
+				semconv.DBOperation(name),
+				attribute.String("db.elasticsearch.path_parts.index", index),
+			),
+		)
+	}
+
+	return ctx, op
+}
+
+// response records the status code of the response elasticsearch returned.
+func (o *operation) response(res *esapi.Response) {
+	if res == nil {
+		return
+	}
+
+	o.status = res.StatusCode
+	if o.span != nil {
+		o.span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	}
+}
+
+// result records the hits of a search, for a scroll it is called for every page. The span holds the total and the time
+// elasticsearch took in milliseconds of the first page.
+func (o *operation) result(total, took int64, returned int) {
+	if o.span != nil && !o.searched {
+		o.span.SetAttributes(
+			attribute.Int64("db.elasticsearch.hits", total),
+			attribute.Int64("db.elasticsearch.took", took),
+		)
+	}
+
+	o.searched = true
+	o.returned += returned
+}
+
+// page counts a page fetched while scrolling.
+func (o *operation) page() {
+	o.pages++
+}
+
+func (o *operation) end(err error) {
+	o.elapsed = time.Since(o.start)
+	if o.instrument != nil && o.instrument.metrics != nil {
+		o.instrument.metrics.observe(o, err)
+	}
+
+	attrs := []any{
+		slog.String("operation", o.name),
+		slog.String("index", o.index),
+		slog.Duration("duration", o.elapsed),
+	}
+	if o.status != 0 {
+		attrs = append(attrs, slog.Int("status", o.status))
+	}
+
+	if o.span != nil {
+		defer o.span.End()
+	}
+
+	if err == nil {
+		o.instrument.log().Debug("elasticsearch request completed", attrs...)
+		return
+	}
+
+	var elasticError *ElasticError
+	if errors.As(err, &elasticError) {
+		attrs = append(attrs, slog.String("type", elasticError.Type))
+	}
+	attrs = append(attrs, slog.String("error", err.Error()))
+
+	if o.span != nil {
+		o.span.RecordError(err)
+		o.span.SetStatus(codes.Error, err.Error())
+	}
+
+	if o.probe {
+		o.instrument.tick("elasticsearch request failed", attrs...)
+		return
+	}
+
+	o.instrument.log().Warn("elasticsearch request failed", attrs...)
+}
@@ -0,0 +1,101 @@
+package aristoteles
+
+import (
+	"bytes"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func bufferLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return logger, &buf
+}
+
+func TestInstrumentLogging(t *testing.T) {
+	index := "test"
+	match := "elastic"
+	word := "isGreat"
+
+	t.Run("RequestFields", func(t *testing.T) {
+		logger, buf := bufferLogger()
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status, WithLogger(logger))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+		_, err = testClient.Query().Match(index, body)
+		assert.Nil(t, err)
+
+		sut := buf.String()
+		assert.Contains(t, sut, `"level":"DEBUG"`)
+		assert.Contains(t, sut, `"operation":"search"`)
+		assert.Contains(t, sut, `"index":"test"`)
+		assert.Contains(t, sut, `"status":200`)
+		assert.Contains(t, sut, `"duration"`)
+	})
+
+	t.Run("FailedRequest", func(t *testing.T) {
+		logger, buf := bufferLogger()
+		file := "deleteIndex404"
+		status := 404
+		testClient, err := NewMockClient(file, status, WithLogger(logger))
+		assert.Nil(t, err)
+
+		_, err = testClient.Index().Delete(index)
+		assert.NotNil(t, err)
+
+		sut := buf.String()
+		assert.Contains(t, sut, `"level":"WARN"`)
+		assert.Contains(t, sut, `"operation":"delete_index"`)
+		assert.Contains(t, sut, `"status":404`)
+		assert.Contains(t, sut, `"type":"index_not_found_exception"`)
+	})
+
+	t.Run("Banner", func(t *testing.T) {
+		logger, buf := bufferLogger()
+		config := models.Config{
+			Service: "http://localhost:9200",
+		}
+
+		_, err := NewClient(config, WithLogger(logger))
+		assert.Nil(t, err)
+		assert.Contains(t, buf.String(), "Education needs these three")
+	})
+
+	t.Run("Quiet", func(t *testing.T) {
+		logger, buf := bufferLogger()
+		config := models.Config{
+			Service: "http://localhost:9200",
+		}
+
+		_, err := NewClient(config, WithLogger(logger), WithQuiet())
+		assert.Nil(t, err)
+		assert.NotContains(t, buf.String(), "Education needs these three")
+
+		file := "infoServiceDown"
+		status := 502
+		testClient, err := NewMockClient(file, status, WithLogger(logger), WithQuiet())
+		assert.Nil(t, err)
+
+		healthy := testClient.Health().Check(10*time.Millisecond, 5*time.Millisecond)
+		assert.False(t, healthy)
+		assert.NotContains(t, buf.String(), "tick")
+	})
+
+	t.Run("NilInstrument", func(t *testing.T) {
+		esClient, err := CreateMockClient("match", 200)
+		assert.Nil(t, err)
+
+		query, err := NewQueryImpl(esClient)
+		assert.Nil(t, err)
+
+		sut, err := query.Match(index, NewBuilderImpl().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), sut.Hits.Total.Value)
+	})
+}
@@ -0,0 +1,286 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/odysseia-greek/aristoteles/models"
+	"log/slog"
+	"time"
+)
+
+const (
+	defaultPageSize  = 100
+	defaultKeepAlive = time.Minute
+	// cleanupTimeout bounds the requests that free a point in time or scroll after the caller is gone.
+	cleanupTimeout = 5 * time.Second
+)
+
+// IteratorConfig configures Iterate, the zero value pages through the hits 100 at a time.
+type IteratorConfig struct {
+	// PageSize is the number of hits per page, defaults to 100.
+	PageSize int
+	// KeepAlive is how long the point in time is kept open between two pages, defaults to one minute.
+	KeepAlive time.Duration
+	// Cursor resumes after the last hit of an earlier iteration. Resuming opens a new point in time, the default sort
+	// on _shard_doc is only stable within one point in time so sort the request on a unique field to resume exactly.
+	Cursor *Cursor
+}
+
+// Cursor is the position of an Iterator, it can be stored as json and passed to a later Iterate.
+type Cursor struct {
+	SearchAfter []interface{} `json:"searchAfter"`
+}
+
+// Iterator pages through the hits of a search with a point in time and search_after. Only the current page is held in
+// memory. The point in time is closed when the last page was read, when a request fails, when the context is done or
+// when Close is called.
+//
+//	it := client.Query().Iterate(ctx, index, request, aristoteles.IteratorConfig{PageSize: 500})
+//	defer it.Close()
+//	for it.Next() {
+//		page := it.Page()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator struct {
+	q           *QueryImpl
+	ctx         context.Context
+	index       string
+	request     map[string]interface{}
+	config      IteratorConfig
+	pitID       string
+	searchAfter []interface{}
+	page        *models.Response
+	err         error
+	done        bool
+}
+
+// Iterate returns an Iterator over the hits of request, no request is sent before the first call to Next.
+func (q *QueryImpl) Iterate(ctx context.Context, index string, request map[string]interface{}, config IteratorConfig) *Iterator {
+	if config.PageSize <= 0 {
+		config.PageSize = defaultPageSize
+	}
+	if config.KeepAlive <= 0 {
+		config.KeepAlive = defaultKeepAlive
+	}
+
+	it := &Iterator{
+		q:       q,
+		ctx:     ctx,
+		index:   index,
+		request: request,
+		config:  config,
+	}
+
+	if config.Cursor != nil {
+		it.searchAfter = config.Cursor.SearchAfter
+	}
+
+	return it
+}
+
+// Next fetches the next page and reports whether it holds any hits.
+func (it *Iterator) Next() bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.fail(err)
+		return false
+	}
+
+	if it.pitID == "" {
+		pitID, err := it.q.openPointInTime(it.ctx, it.index, it.config.KeepAlive)
+		if err != nil {
+			it.fail(err)
+			return false
+		}
+		it.pitID = pitID
+	}
+
+	page, err := it.q.searchAfter(it.ctx, it.index, it.body())
+	if err != nil {
+		it.fail(err)
+		return false
+	}
+
+	if page.PitId != "" {
+		it.pitID = page.PitId
+	}
+
+	if len(page.Hits.Hits) == 0 {
+		it.page = nil
+		it.finish()
+		return false
+	}
+
+	it.page = page
+	it.searchAfter = page.Hits.Hits[len(page.Hits.Hits)-1].Sort
+
+	if len(page.Hits.Hits) < it.config.PageSize {
+		it.finish()
+	}
+
+	return true
+}
+
+// Page returns the page fetched by the last call to Next.
+func (it *Iterator) Page() *models.Response {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, a done context included.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the position after the current page, nil before the first page.
+func (it *Iterator) Cursor() *Cursor {
+	if it.searchAfter == nil {
+		return nil
+	}
+
+	return &Cursor{SearchAfter: it.searchAfter}
+}
+
+// Close stops the iteration and closes the point in time, it is safe to call more than once.
+func (it *Iterator) Close() error {
+	it.done = true
+	if it.pitID == "" {
+		return nil
+	}
+
+	pitID := it.pitID
+	it.pitID = ""
+
+	return it.q.closePointInTime(it.ctx, it.index, pitID)
+}
+
+func (it *Iterator) finish() {
+	if err := it.Close(); err != nil {
+		it.q.instrument.log().Warn("closing point in time failed", slog.String("index", it.index), slog.String("error", err.Error()))
+	}
+}
+
+func (it *Iterator) fail(err error) {
+	it.err = err
+	it.finish()
+}
+
+// body adds the point in time and position to the request of the caller without changing it.
+func (it *Iterator) body() map[string]interface{} {
+	body := make(map[string]interface{}, len(it.request)+4)
+	for key, value := range it.request {
+		body[key] = value
+	}
+
+	body["size"] = it.config.PageSize
+	body["pit"] = map[string]interface{}{
+		"id":         it.pitID,
+		"keep_alive": fmt.Sprintf("%dms", it.config.KeepAlive.Milliseconds()),
+	}
+
+	if _, ok := body["sort"]; !ok {
+		body["sort"] = []interface{}{"_shard_doc"}
+	}
+
+	if it.searchAfter != nil {
+		body["search_after"] = it.searchAfter
+	}
+
+	return body
+}
+
+func (q *QueryImpl) openPointInTime(ctx context.Context, index string, keepAlive time.Duration) (pitID string, err error) {
+	ctx, op := q.instrument.begin(ctx, "open_point_in_time", index)
+	defer func() { op.end(err) }()
+
+	res, err := q.es.OpenPointInTime(
+		[]string{index},
+		fmt.Sprintf("%dms", keepAlive.Milliseconds()),
+		q.es.OpenPointInTime.WithContext(ctx),
+	)
+	op.response(res)
+
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", newElasticError(res)
+	}
+
+	var pit struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&pit); err != nil {
+		return "", err
+	}
+
+	return pit.ID, nil
+}
+
+func (q *QueryImpl) searchAfter(ctx context.Context, index string, body map[string]interface{}) (result *models.Response, err error) {
+	ctx, op := q.instrument.begin(ctx, "iterate", index)
+	defer func() { op.end(err) }()
+
+	query, err := toBuffer(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// the index is part of the point in time and must not be in the path
+	res, err := q.es.Search(
+		q.es.Search.WithContext(ctx),
+		q.es.Search.WithBody(&query),
+	)
+	op.response(res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = q.parseResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	op.result(result.Hits.Total.Value, result.Took, len(result.Hits.Hits))
+	op.page()
+
+	return result, nil
+}
+
+// closePointInTime frees the point in time, it runs after ctx is cancelled as well.
+func (q *QueryImpl) closePointInTime(ctx context.Context, index, pitID string) (err error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	ctx, op := q.instrument.begin(ctx, "close_point_in_time", index)
+	defer func() { op.end(err) }()
+
+	body, err := toBuffer(map[string]interface{}{"id": pitID})
+	if err != nil {
+		return err
+	}
+
+	res, err := q.es.ClosePointInTime(
+		q.es.ClosePointInTime.WithContext(ctx),
+		q.es.ClosePointInTime.WithBody(&body),
+	)
+	op.response(res)
+
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return newElasticError(res)
+	}
+
+	return nil
+}
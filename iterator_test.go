@@ -0,0 +1,125 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	index := "test"
+	config := models.Config{
+		Service: "http://localhost:9200",
+	}
+	pages := []MockResponse{
+		{Fixture: "openPointInTime", StatusCode: 200},
+		{Fixture: "pointInTimeFirstPage", StatusCode: 200},
+		{Fixture: "pointInTimeLastPage", StatusCode: 200},
+		{Fixture: "closePointInTime", StatusCode: 200},
+	}
+
+	t.Run("Pages", func(t *testing.T) {
+		transport := NewSequentialTransport(pages...)
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		it := testClient.Query().Iterate(context.Background(), index, testClient.Builder().MatchAll(), IteratorConfig{PageSize: 2})
+		defer it.Close()
+
+		var greek []string
+		for it.Next() {
+			for _, hit := range it.Page().Hits.Hits {
+				greek = append(greek, hit.Source["greek"].(string))
+			}
+		}
+		assert.Nil(t, it.Err())
+		assert.Equal(t, []string{"πόλεμος", "λόγος", "ἀρετή"}, greek)
+		assert.Equal(t, []interface{}{float64(2)}, it.Cursor().SearchAfter)
+
+		requests := transport.Requests()
+		assert.Equal(t, 4, len(requests))
+		assert.Equal(t, "/test/_pit", requests[0].URL.Path)
+		assert.Equal(t, "/_search", requests[1].URL.Path)
+		assert.Equal(t, float64(2), requests[1].JSON()["size"])
+		assert.Equal(t, []interface{}{"_shard_doc"}, requests[1].JSON()["sort"])
+		assert.Nil(t, requests[1].JSON()["search_after"])
+		assert.Equal(t, "60000ms", requests[1].JSON()["pit"].(map[string]interface{})["keep_alive"])
+		assert.Equal(t, []interface{}{float64(1)}, requests[2].JSON()["search_after"])
+		assert.Equal(t, http.MethodDelete, requests[3].Method)
+		assert.Equal(t, "/_pit", requests[3].URL.Path)
+		assert.NotEmpty(t, requests[3].JSON()["id"])
+	})
+
+	t.Run("EmptyLastPage", func(t *testing.T) {
+		transport := NewSequentialTransport(
+			MockResponse{Fixture: "openPointInTime", StatusCode: 200},
+			MockResponse{Fixture: "pointInTimeFirstPage", StatusCode: 200},
+			MockResponse{Fixture: "matchEmptyHits", StatusCode: 200},
+			MockResponse{Fixture: "closePointInTime", StatusCode: 200},
+		)
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		it := testClient.Query().Iterate(context.Background(), index, testClient.Builder().MatchAll(), IteratorConfig{PageSize: 2})
+
+		assert.True(t, it.Next())
+		assert.False(t, it.Next())
+		assert.Nil(t, it.Err())
+		assert.Nil(t, it.Page())
+		assert.Nil(t, it.Close())
+		assert.Equal(t, 4, len(transport.Requests()))
+	})
+
+	t.Run("ResumeFromCursor", func(t *testing.T) {
+		transport := NewSequentialTransport(pages...)
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		var cursor Cursor
+		assert.Nil(t, json.Unmarshal([]byte(`{"searchAfter":[1]}`), &cursor))
+
+		it := testClient.Query().Iterate(context.Background(), index, testClient.Builder().MatchAll(), IteratorConfig{Cursor: &cursor})
+		defer it.Close()
+
+		assert.True(t, it.Next())
+		assert.Equal(t, []interface{}{float64(1)}, transport.Requests()[1].JSON()["search_after"])
+		assert.Equal(t, float64(defaultPageSize), transport.Requests()[1].JSON()["size"])
+	})
+
+	t.Run("ClosesOnCancel", func(t *testing.T) {
+		transport := NewSequentialTransport(pages...)
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		it := testClient.Query().Iterate(ctx, index, testClient.Builder().MatchAll(), IteratorConfig{PageSize: 2})
+
+		assert.True(t, it.Next())
+		cancel()
+		assert.False(t, it.Next())
+		assert.ErrorIs(t, it.Err(), context.Canceled)
+
+		assert.Equal(t, 3, len(transport.Requests()))
+		assert.Equal(t, http.MethodDelete, transport.Requests()[2].Method)
+	})
+
+	t.Run("ClosesOnError", func(t *testing.T) {
+		transport := NewSequentialTransport(
+			MockResponse{Fixture: "openPointInTime", StatusCode: 200},
+			MockResponse{Fixture: "serviceDown", StatusCode: 502},
+			MockResponse{Fixture: "closePointInTime", StatusCode: 200},
+		)
+		testClient, err := NewClient(config, WithMaxRetries(0), WithTransport(transport))
+		assert.Nil(t, err)
+
+		it := testClient.Query().Iterate(context.Background(), index, testClient.Builder().MatchAll(), IteratorConfig{})
+
+		assert.False(t, it.Next())
+		assert.True(t, IsRetryable(it.Err()))
+		assert.Equal(t, 3, len(transport.Requests()))
+		assert.Equal(t, http.MethodDelete, transport.Requests()[2].Method)
+	})
+}
@@ -0,0 +1,109 @@
+package aristoteles
+
+import (
+	"context"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+)
+
+const metricsNamespace = "aristoteles"
+
+// Metrics records client side metrics of every operation, labelled by index and operation. It implements
+// prometheus.Collector and can be shared by several clients, register it once with the registry of the service.
+type Metrics struct {
+	duration    *prometheus.HistogramVec
+	errors      *prometheus.CounterVec
+	scrollPages *prometheus.CounterVec
+	hits        *prometheus.HistogramVec
+}
+
+// NewMetrics creates the collectors, pass the result to WithMetrics to record the operations of a client.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of elasticsearch operations, including retries and reading the response.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"index", "operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_errors_total",
+			Help:      "Failed elasticsearch operations by status code and error type.",
+		}, []string{"index", "operation", "status", "type"}),
+		scrollPages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "scroll_pages_total",
+			Help:      "Pages fetched by scrolling searches.",
+		}, []string{"index"}),
+		hits: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "search_hits",
+			Help:      "Number of hits returned by a search.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"index", "operation"}),
+	}
+}
+
+// WithMetrics records every operation of the client in metrics.
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *settings) {
+		s.metrics = metrics
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.duration.Describe(ch)
+	m.errors.Describe(ch)
+	m.scrollPages.Describe(ch)
+	m.hits.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.duration.Collect(ch)
+	m.errors.Collect(ch)
+	m.scrollPages.Collect(ch)
+	m.hits.Collect(ch)
+}
+
+func (m *Metrics) observe(o *operation, err error) {
+	m.duration.WithLabelValues(o.index, o.name).Observe(o.elapsed.Seconds())
+
+	if o.pages > 0 {
+		m.scrollPages.WithLabelValues(o.index).Add(float64(o.pages))
+	}
+
+	if err != nil {
+		status := ""
+		if o.status != 0 {
+			status = strconv.Itoa(o.status)
+		}
+		m.errors.WithLabelValues(o.index, o.name, status, errorType(err)).Inc()
+		return
+	}
+
+	if o.searched {
+		m.hits.WithLabelValues(o.index, o.name).Observe(float64(o.returned))
+	}
+}
+
+// errorType is the elasticsearch error type, or a short description of errors that happened in the client.
+func errorType(err error) string {
+	var elasticError *ElasticError
+	switch {
+	case errors.As(err, &elasticError):
+		if elasticError.Type == "" {
+			return "unknown"
+		}
+		return elasticError.Type
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	default:
+		return "client"
+	}
+}
@@ -0,0 +1,93 @@
+package aristoteles
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	index := "test"
+
+	t.Run("Register", func(t *testing.T) {
+		registry := prometheus.NewPedanticRegistry()
+		err := registry.Register(NewMetrics())
+		assert.Nil(t, err)
+	})
+
+	t.Run("SearchDurationAndHits", func(t *testing.T) {
+		metrics := NewMetrics()
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status, WithMetrics(metrics))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, testutil.CollectAndCount(metrics, "aristoteles_request_duration_seconds"))
+		assert.Equal(t, 0, testutil.CollectAndCount(metrics, "aristoteles_request_errors_total"))
+
+		expected := `
+# HELP aristoteles_search_hits Number of hits returned by a search.
+# TYPE aristoteles_search_hits histogram
+aristoteles_search_hits_bucket{index="test",operation="search",le="1"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="4"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="16"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="64"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="256"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="1024"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="4096"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="16384"} 1
+aristoteles_search_hits_bucket{index="test",operation="search",le="+Inf"} 1
+aristoteles_search_hits_sum{index="test",operation="search"} 1
+aristoteles_search_hits_count{index="test",operation="search"} 1
+`
+		err = testutil.CollectAndCompare(metrics, strings.NewReader(expected), "aristoteles_search_hits")
+		assert.Nil(t, err)
+	})
+
+	t.Run("ErrorsByStatusAndType", func(t *testing.T) {
+		metrics := NewMetrics()
+		file := "deleteIndex404"
+		status := 404
+		testClient, err := NewMockClient(file, status, WithMetrics(metrics))
+		assert.Nil(t, err)
+
+		_, err = testClient.Index().Delete(index)
+		assert.NotNil(t, err)
+
+		expected := `
+# HELP aristoteles_request_errors_total Failed elasticsearch operations by status code and error type.
+# TYPE aristoteles_request_errors_total counter
+aristoteles_request_errors_total{index="test",operation="delete_index",status="404",type="index_not_found_exception"} 1
+`
+		err = testutil.CollectAndCompare(metrics, strings.NewReader(expected), "aristoteles_request_errors_total")
+		assert.Nil(t, err)
+	})
+
+	t.Run("ScrollPages", func(t *testing.T) {
+		metrics := NewMetrics()
+		file := "matchEmptyScroll"
+		status := 200
+		testClient, err := NewMockClient(file, status, WithMetrics(metrics))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().MatchWithScroll(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(metrics.scrollPages.WithLabelValues(index)))
+	})
+
+	t.Run("WithoutMetrics", func(t *testing.T) {
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+	})
+}
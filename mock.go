@@ -2,6 +2,7 @@ package aristoteles
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 var (
@@ -69,19 +71,7 @@ func (t *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 }
 
 func CreateMockClient(fixtureFile string, statusCode int) (*elasticsearch.Client, error) {
-	mockCode := 500
-	switch statusCode {
-	case 200:
-		mockCode = http.StatusOK
-	case 404:
-		mockCode = http.StatusNotFound
-	case 500:
-		mockCode = http.StatusInternalServerError
-	case 502:
-		mockCode = http.StatusBadGateway
-	default:
-		mockCode = 200
-	}
+	mockCode := mockStatus(statusCode)
 
 	body := fixture(fmt.Sprintf("%s.json", fixtureFile))
 	mockTrans := MockTransport{
@@ -103,6 +93,109 @@ func CreateMockClient(fixtureFile string, statusCode int) (*elasticsearch.Client
 	return client, nil
 }
 
+// MockResponse is a single response of a SequentialTransport, Fixture is the name of a file in eratosthenes without
+// the .json extension.
+type MockResponse struct {
+	Fixture    string
+	StatusCode int
+}
+
+// RecordedRequest is a request received by a SequentialTransport, its Body can be read again.
+type RecordedRequest struct {
+	*http.Request
+	Content []byte
+}
+
+// JSON decodes Content, it is nil when the body is empty or not a json object.
+func (r RecordedRequest) JSON() map[string]interface{} {
+	var body map[string]interface{}
+	if err := json.Unmarshal(r.Content, &body); err != nil {
+		return nil
+	}
+
+	return body
+}
+
+// SequentialTransport answers every request with the next response, the last response is repeated once all responses
+// were used. Every request is recorded so tests can check what was sent. Pass it to WithTransport.
+type SequentialTransport struct {
+	mu        sync.Mutex
+	responses []MockResponse
+	requests  []RecordedRequest
+}
+
+func NewSequentialTransport(responses ...MockResponse) *SequentialTransport {
+	return &SequentialTransport{responses: responses}
+}
+
+func (t *SequentialTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var content []byte
+	if req.Body != nil {
+		var err error
+		content, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := req.Clone(req.Context())
+	recorded.Body = io.NopCloser(bytes.NewReader(content))
+
+	t.mu.Lock()
+	response := MockResponse{Fixture: "match", StatusCode: http.StatusOK}
+	if len(t.responses) > 0 {
+		response = t.responses[len(t.responses)-1]
+		if len(t.requests) < len(t.responses) {
+			response = t.responses[len(t.requests)]
+		}
+	}
+	t.requests = append(t.requests, RecordedRequest{Request: recorded, Content: content})
+	t.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: response.StatusCode,
+		Body:       fixture(fmt.Sprintf("%s.json", response.Fixture)),
+		Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+	}, nil
+}
+
+// Requests returns the requests received so far in order.
+func (t *SequentialTransport) Requests() []RecordedRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]RecordedRequest(nil), t.requests...)
+}
+
+func mockStatus(statusCode int) int {
+	mockCode := 500
+	switch statusCode {
+	case 200:
+		mockCode = http.StatusOK
+	case 401:
+		mockCode = http.StatusUnauthorized
+	case 403:
+		mockCode = http.StatusForbidden
+	case 404:
+		mockCode = http.StatusNotFound
+	case 409:
+		mockCode = http.StatusConflict
+	case 429:
+		mockCode = http.StatusTooManyRequests
+	case 500:
+		mockCode = http.StatusInternalServerError
+	case 502:
+		mockCode = http.StatusBadGateway
+	case 503:
+		mockCode = http.StatusServiceUnavailable
+	default:
+		mockCode = 200
+	}
+
+	return mockCode
+}
+
 func CreateEmptyClient() (*elasticsearch.Client, error) {
 	mockTrans := MockTransport{
 		Response: &http.Response{
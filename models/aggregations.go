@@ -0,0 +1,210 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// AggregationResults holds the aggregations of a response by the name they were requested with.
+type AggregationResults map[string]AggregationResult
+
+// Get returns the aggregation called name.
+func (a AggregationResults) Get(name string) (AggregationResult, bool) {
+	result, ok := a[name]
+	return result, ok
+}
+
+// Buckets returns the buckets of the bucket aggregation called name, for example a terms or histogram aggregation.
+func (a AggregationResults) Buckets(name string) []Bucket {
+	return a[name].Buckets
+}
+
+// Value returns the value of the single value metric aggregation called name, such as cardinality, min, max, avg or
+// sum. It reports false when the aggregation is missing or has no value, like the max of no documents.
+func (a AggregationResults) Value(name string) (float64, bool) {
+	return a[name].Value()
+}
+
+// AggregationResult is the result of any aggregation. Bucket aggregations fill Buckets, metric aggregations fill
+// Values and single bucket aggregations such as nested or filter fill DocCount and Aggregations.
+type AggregationResult struct {
+	DocCountErrorUpperBound int64
+	SumOtherDocCount        int64
+	DocCount                int64
+	Buckets                 []Bucket
+	// Values holds the numbers of a metric aggregation by name: value for single value metrics and count, min, max,
+	// avg and sum for stats.
+	Values        map[string]float64
+	ValueAsString string
+	Aggregations  AggregationResults
+	// Raw is the aggregation as elasticsearch returned it, for results the accessors do not cover.
+	Raw json.RawMessage
+}
+
+// Value returns the value of a single value metric aggregation.
+func (a AggregationResult) Value() (float64, bool) {
+	value, ok := a.Values["value"]
+	return value, ok
+}
+
+// Bucket returns the bucket with the given key, keys are compared as returned by elasticsearch and as key_as_string.
+// Numbers are float64 and the key of a composite bucket is a map[string]interface{} of the sources.
+func (a AggregationResult) Bucket(key interface{}) (Bucket, bool) {
+	for _, bucket := range a.Buckets {
+		if reflect.DeepEqual(bucket.Key, key) || (bucket.KeyAsString != "" && bucket.KeyAsString == key) {
+			return bucket, true
+		}
+	}
+
+	return Bucket{}, false
+}
+
+func (a *AggregationResult) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*a = AggregationResult{Raw: append(json.RawMessage(nil), data...)}
+	for key, value := range fields {
+		var err error
+		switch key {
+		case "meta":
+		case "doc_count_error_upper_bound":
+			err = json.Unmarshal(value, &a.DocCountErrorUpperBound)
+		case "sum_other_doc_count":
+			err = json.Unmarshal(value, &a.SumOtherDocCount)
+		case "doc_count":
+			err = json.Unmarshal(value, &a.DocCount)
+		case "value_as_string":
+			err = json.Unmarshal(value, &a.ValueAsString)
+		case "buckets":
+			a.Buckets, err = unmarshalBuckets(value)
+		default:
+			a.Values, a.Aggregations, err = unmarshalField(key, value, a.Values, a.Aggregations)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a AggregationResult) MarshalJSON() ([]byte, error) {
+	if a.Raw == nil {
+		return []byte("{}"), nil
+	}
+
+	return a.Raw, nil
+}
+
+func (b *Bucket) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	*b = Bucket{}
+	for key, value := range fields {
+		var err error
+		switch key {
+		case "key":
+			err = json.Unmarshal(value, &b.Key)
+		case "key_as_string":
+			err = json.Unmarshal(value, &b.KeyAsString)
+		case "doc_count":
+			err = json.Unmarshal(value, &b.DocCount)
+		default:
+			b.Values, b.Aggregations, err = unmarshalField(key, value, b.Values, b.Aggregations)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON writes the Values and Aggregations back next to the key and doc_count, as elasticsearch returned them.
+func (b Bucket) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(b.Values)+len(b.Aggregations)+3)
+	for key, value := range b.Values {
+		fields[key] = value
+	}
+	for key, aggregation := range b.Aggregations {
+		fields[key] = aggregation
+	}
+
+	fields["key"] = b.Key
+	if b.KeyAsString != "" {
+		fields["key_as_string"] = b.KeyAsString
+	}
+	fields["doc_count"] = b.DocCount
+
+	return json.Marshal(fields)
+}
+
+// unmarshalBuckets accepts the list elasticsearch returns by default and the object returned for keyed aggregations,
+// keyed buckets are ordered by key.
+func unmarshalBuckets(data json.RawMessage) ([]Bucket, error) {
+	var buckets []Bucket
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		err := json.Unmarshal(data, &buckets)
+		return buckets, err
+	}
+
+	var keyed map[string]Bucket
+	if err := json.Unmarshal(data, &keyed); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(keyed))
+	for key := range keyed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		bucket := keyed[key]
+		if bucket.Key == nil {
+			bucket.Key = key
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return buckets, nil
+}
+
+// unmarshalField adds a field that is not known up front: numbers are metric values and objects are sub aggregations.
+func unmarshalField(key string, value json.RawMessage, values map[string]float64, aggregations AggregationResults) (map[string]float64, AggregationResults, error) {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 {
+		return values, aggregations, nil
+	}
+
+	switch trimmed[0] {
+	case '{':
+		var aggregation AggregationResult
+		if err := json.Unmarshal(trimmed, &aggregation); err != nil {
+			return values, aggregations, err
+		}
+		if aggregations == nil {
+			aggregations = AggregationResults{}
+		}
+		aggregations[key] = aggregation
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		var number float64
+		if err := json.Unmarshal(trimmed, &number); err != nil {
+			return values, aggregations, err
+		}
+		if values == nil {
+			values = map[string]float64{}
+		}
+		values[key] = number
+	}
+
+	return values, aggregations, nil
+}
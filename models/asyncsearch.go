@@ -0,0 +1,33 @@
+package models
+
+import "encoding/json"
+
+func UnmarshalAsyncSearchResponse(data []byte) (AsyncSearchResponse, error) {
+	var r AsyncSearchResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+// AsyncSearchResponse is the state of an async search. Response holds the hits and aggregations gathered so far, they
+// are final once IsRunning is false and IsPartial is false.
+type AsyncSearchResponse struct {
+	ID                     string   `json:"id,omitempty"`
+	IsPartial              bool     `json:"is_partial"`
+	IsRunning              bool     `json:"is_running"`
+	StartTimeInMillis      int64    `json:"start_time_in_millis"`
+	ExpirationTimeInMillis int64    `json:"expiration_time_in_millis"`
+	CompletionTimeInMillis int64    `json:"completion_time_in_millis,omitempty"`
+	Response               Response `json:"response"`
+}
+
+// AsyncSearchStatus is the progress of an async search without its results. CompletionStatus is the status code of
+// the finished search and zero while it runs.
+type AsyncSearchStatus struct {
+	ID                     string `json:"id"`
+	IsPartial              bool   `json:"is_partial"`
+	IsRunning              bool   `json:"is_running"`
+	StartTimeInMillis      int64  `json:"start_time_in_millis"`
+	ExpirationTimeInMillis int64  `json:"expiration_time_in_millis"`
+	CompletionStatus       int    `json:"completion_status,omitempty"`
+	Shards                 Shards `json:"_shards"`
+}
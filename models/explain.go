@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Explanation is how the score of a hit was computed. Value is the outcome of Description applied to Details, so the
+// leaves of the tree hold the term statistics and the root holds the _score.
+type Explanation struct {
+	Value       float64       `json:"value"`
+	Description string        `json:"description"`
+	Details     []Explanation `json:"details,omitempty"`
+}
+
+// String renders the explanation as an indented tree, one line per node.
+func (e Explanation) String() string {
+	var builder strings.Builder
+	e.write(&builder, 0)
+	return builder.String()
+}
+
+func (e Explanation) write(builder *strings.Builder, depth int) {
+	fmt.Fprintf(builder, "%s%g %s\n", strings.Repeat("  ", depth), e.Value, e.Description)
+	for _, detail := range e.Details {
+		detail.write(builder, depth+1)
+	}
+}
+
+// Profile holds the timings of a search per shard.
+type Profile struct {
+	Shards []ShardProfile `json:"shards"`
+}
+
+type ShardProfile struct {
+	ID           string          `json:"id"`
+	Searches     []SearchProfile `json:"searches"`
+	Aggregations []ProfileResult `json:"aggregations,omitempty"`
+}
+
+type SearchProfile struct {
+	Query       []ProfileResult    `json:"query"`
+	RewriteTime int64              `json:"rewrite_time"`
+	Collector   []CollectorProfile `json:"collector"`
+}
+
+// ProfileResult is the timing of one query or aggregation, Breakdown holds the nanoseconds spent per step such as
+// create_weight, build_scorer and next_doc.
+type ProfileResult struct {
+	Type        string           `json:"type"`
+	Description string           `json:"description"`
+	TimeInNanos int64            `json:"time_in_nanos"`
+	Breakdown   map[string]int64 `json:"breakdown,omitempty"`
+	Children    []ProfileResult  `json:"children,omitempty"`
+}
+
+// Time returns TimeInNanos as a duration.
+func (p ProfileResult) Time() time.Duration {
+	return time.Duration(p.TimeInNanos)
+}
+
+type CollectorProfile struct {
+	Name        string             `json:"name"`
+	Reason      string             `json:"reason"`
+	TimeInNanos int64              `json:"time_in_nanos"`
+	Children    []CollectorProfile `json:"children,omitempty"`
+}
+
+// Time returns TimeInNanos as a duration.
+func (c CollectorProfile) Time() time.Duration {
+	return time.Duration(c.TimeInNanos)
+}
@@ -17,4 +17,5 @@ type DatabaseHealth struct {
 	ClusterName   string `json:"clusterName,omitempty"`
 	ServerName    string `json:"serverName,omitempty"`
 	ServerVersion string `json:"serverVersion,omitempty"`
+	Circuit       string `json:"circuit,omitempty"`
 }
@@ -13,11 +13,15 @@ func (r *Response) Marshal() ([]byte, error) {
 }
 
 type Response struct {
-	ScrollId string `json:"_scroll_id,omitempty"`
-	Took     int64  `json:"took"`
-	TimedOut bool   `json:"timed_out"`
-	Shards   Shards `json:"_shards"`
-	Hits     Hits   `json:"hits"`
+	ScrollId     string                  `json:"_scroll_id,omitempty"`
+	PitId        string                  `json:"pit_id,omitempty"`
+	Took         int64                   `json:"took"`
+	TimedOut     bool                    `json:"timed_out"`
+	Shards       Shards                  `json:"_shards"`
+	Hits         Hits                    `json:"hits"`
+	Aggregations AggregationResults      `json:"aggregations,omitempty"`
+	Suggest      map[string][]Suggestion `json:"suggest,omitempty"`
+	Profile      *Profile                `json:"profile,omitempty"`
 }
 
 type Hits struct {
@@ -27,11 +31,25 @@ type Hits struct {
 }
 
 type Hit struct {
-	Index  string                 `json:"_index"`
-	Type   string                 `json:"_type"`
-	ID     string                 `json:"_id"`
-	Score  float64                `json:"_score"`
-	Source map[string]interface{} `json:"_source"`
+	Index     string                 `json:"_index"`
+	Type      string                 `json:"_type"`
+	ID        string                 `json:"_id"`
+	Score     float64                `json:"_score"`
+	Source    map[string]interface{} `json:"_source"`
+	Sort      []interface{}          `json:"sort,omitempty"`
+	Highlight map[string][]string    `json:"highlight,omitempty"`
+	// Shard, Node and Explanation are only set when the search ran with explain.
+	Shard       string       `json:"_shard,omitempty"`
+	Node        string       `json:"_node,omitempty"`
+	Explanation *Explanation `json:"_explanation,omitempty"`
+	// Fields holds the stored fields, docvalue fields and collapse field of a hit, every field as a list of values.
+	Fields map[string][]interface{} `json:"fields,omitempty"`
+	// InnerHits holds the inner hits of a collapsed hit by the name they were requested with.
+	InnerHits map[string]InnerHits `json:"inner_hits,omitempty"`
+}
+
+type InnerHits struct {
+	Hits Hits `json:"hits"`
 }
 
 type Total struct {
@@ -64,10 +82,28 @@ type Aggregations struct {
 	Aggregations AuthorAggregations `json:"aggregations"`
 }
 
+// AuthorAggregations decodes the authors, books and categories aggregations odysseia requests, every aggregation
+// including those is available by name in ByName.
 type AuthorAggregations struct {
-	AuthorAggregation   Aggregation `json:"authors"`
-	BookAggregation     Aggregation `json:"books"`
-	CategoryAggregation Aggregation `json:"categories"`
+	AuthorAggregation   Aggregation        `json:"authors"`
+	BookAggregation     Aggregation        `json:"books"`
+	CategoryAggregation Aggregation        `json:"categories"`
+	ByName              AggregationResults `json:"-"`
+}
+
+func (a *AuthorAggregations) UnmarshalJSON(data []byte) error {
+	type authorAggregations AuthorAggregations
+	var aggregations authorAggregations
+	if err := json.Unmarshal(data, &aggregations); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, &aggregations.ByName); err != nil {
+		return err
+	}
+
+	*a = AuthorAggregations(aggregations)
+	return nil
 }
 
 type Aggregation struct {
@@ -77,8 +113,12 @@ type Aggregation struct {
 }
 
 type Bucket struct {
-	Key      interface{} `json:"key"`
-	DocCount int64       `json:"doc_count"`
+	Key         interface{} `json:"key"`
+	KeyAsString string      `json:"key_as_string,omitempty"`
+	DocCount    int64       `json:"doc_count"`
+	// Values holds numbers of the bucket other than doc_count, such as from and to of a range bucket.
+	Values       map[string]float64 `json:"-"`
+	Aggregations AggregationResults `json:"-"`
 }
 
 func UnmarshalCreateRoleRequest(data []byte) (CreateRoleRequest, error) {
@@ -138,11 +178,18 @@ type CreateUserRequest struct {
 	Metadata *Metadata `json:"metadata"`
 }
 
+// Config holds the connection details for elasticsearch. When more than one way of authenticating is set the
+// BearerToken takes precedence over the APIKey, which takes precedence over the ServiceToken and then Username/Password.
 type Config struct {
-	Service     string `json:"elasticService"`
-	Username    string `json:"elasticUsername"`
-	Password    string `json:"elasticPassword"`
-	ElasticCERT string `json:"elasticCert"`
+	Service      string `json:"elasticService"`
+	Username     string `json:"elasticUsername"`
+	Password     string `json:"elasticPassword"`
+	APIKey       string `json:"elasticApiKey"`
+	BearerToken  string `json:"elasticBearerToken"`
+	ServiceToken string `json:"elasticServiceToken"`
+	ElasticCERT  string `json:"elasticCert"`
+	ClientCERT   string `json:"elasticClientCert"`
+	ClientKey    string `json:"elasticClientKey"`
 }
 
 func UnmarshalCreateResult(data []byte) (CreateResult, error) {
@@ -183,23 +230,46 @@ type IndexCreateResult struct {
 }
 
 type IndexError struct {
-	Error struct {
-		RootCause []struct {
-			Type         string `json:"type"`
-			Reason       string `json:"reason"`
-			ResourceType string `json:"resource.type"`
-			ResourceId   string `json:"resource.id"`
-			IndexUuid    string `json:"index_uuid"`
-			Index        string `json:"index"`
-		} `json:"root_cause"`
-		Type         string `json:"type"`
-		Reason       string `json:"reason"`
-		ResourceType string `json:"resource.type"`
-		ResourceId   string `json:"resource.id"`
-		IndexUuid    string `json:"index_uuid"`
-		Index        string `json:"index"`
-	} `json:"error"`
-	Status int `json:"status"`
+	Error  ErrorCause `json:"error"`
+	Status int        `json:"status"`
+}
+
+type ErrorCause struct {
+	Type         string         `json:"type"`
+	Reason       string         `json:"reason"`
+	ResourceType string         `json:"resource.type,omitempty"`
+	ResourceId   string         `json:"resource.id,omitempty"`
+	IndexUuid    string         `json:"index_uuid,omitempty"`
+	Index        string         `json:"index,omitempty"`
+	Phase        string         `json:"phase,omitempty"`
+	RootCause    []ErrorCause   `json:"root_cause,omitempty"`
+	FailedShards []ShardFailure `json:"failed_shards,omitempty"`
+	CausedBy     *ErrorCause    `json:"caused_by,omitempty"`
+}
+
+// UnmarshalJSON also accepts a plain string, which is what proxies in front of elasticsearch tend to return as error.
+func (e *ErrorCause) UnmarshalJSON(data []byte) error {
+	var reason string
+	if err := json.Unmarshal(data, &reason); err == nil {
+		*e = ErrorCause{Reason: reason}
+		return nil
+	}
+
+	type errorCause ErrorCause
+	var cause errorCause
+	if err := json.Unmarshal(data, &cause); err != nil {
+		return err
+	}
+
+	*e = ErrorCause(cause)
+	return nil
+}
+
+type ShardFailure struct {
+	Shard  int64      `json:"shard"`
+	Index  string     `json:"index"`
+	Node   string     `json:"node,omitempty"`
+	Reason ErrorCause `json:"reason"`
 }
 
 func UnmarshalIndexError(data []byte) (IndexError, error) {
@@ -207,3 +277,53 @@ func UnmarshalIndexError(data []byte) (IndexError, error) {
 	err := json.Unmarshal(data, &r)
 	return r, err
 }
+
+func UnmarshalCountResponse(data []byte) (CountResponse, error) {
+	var r CountResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+type CountResponse struct {
+	Count  int64  `json:"count"`
+	Shards Shards `json:"_shards"`
+}
+
+// Suggestion holds the options for the part of the input text starting at Offset.
+type Suggestion struct {
+	Text    string          `json:"text"`
+	Offset  int             `json:"offset"`
+	Length  int             `json:"length"`
+	Options []SuggestOption `json:"options"`
+}
+
+// SuggestOption is a single suggestion. Term suggesters set Freq, phrase suggesters set Highlighted and completion
+// suggesters set the document the suggestion comes from.
+type SuggestOption struct {
+	Text        string                 `json:"text"`
+	Score       float64                `json:"score"`
+	Freq        int64                  `json:"freq,omitempty"`
+	Highlighted string                 `json:"highlighted,omitempty"`
+	Index       string                 `json:"_index,omitempty"`
+	ID          string                 `json:"_id,omitempty"`
+	Source      map[string]interface{} `json:"_source,omitempty"`
+}
+
+// UnmarshalJSON reads the score of completion suggesters, which elasticsearch returns as _score.
+func (o *SuggestOption) UnmarshalJSON(data []byte) error {
+	type suggestOption SuggestOption
+	var option struct {
+		suggestOption
+		DocumentScore *float64 `json:"_score"`
+	}
+	if err := json.Unmarshal(data, &option); err != nil {
+		return err
+	}
+
+	*o = SuggestOption(option.suggestOption)
+	if option.DocumentScore != nil {
+		o.Score = *option.DocumentScore
+	}
+
+	return nil
+}
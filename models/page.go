@@ -0,0 +1,34 @@
+package models
+
+// Page is one page of the hits of a search. Page numbers start at 1.
+type Page[T any] struct {
+	Items    []T   `json:"items"`
+	Total    int64 `json:"total"`
+	Page     int   `json:"page"`
+	PageSize int   `json:"pageSize"`
+	HasMore  bool  `json:"hasMore"`
+}
+
+// NewPage creates the page number page of size items out of total hits.
+func NewPage[T any](items []T, total int64, page, size int) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return Page[T]{
+		Items:    items,
+		Total:    total,
+		Page:     page,
+		PageSize: size,
+		HasMore:  int64((page-1)*size+len(items)) < total,
+	}
+}
+
+// TotalPages returns the number of pages of PageSize needed for Total.
+func (p Page[T]) TotalPages() int {
+	if p.PageSize <= 0 {
+		return 0
+	}
+
+	return int((p.Total + int64(p.PageSize) - 1) / int64(p.PageSize))
+}
@@ -0,0 +1,47 @@
+package models
+
+import "encoding/json"
+
+func UnmarshalSearchResponse[T any](data []byte) (SearchResponse[T], error) {
+	var r SearchResponse[T]
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+// SearchResponse is a Response with the _source of every hit decoded into T.
+type SearchResponse[T any] struct {
+	Took         int64              `json:"took"`
+	TimedOut     bool               `json:"timed_out"`
+	Shards       Shards             `json:"_shards"`
+	Hits         SearchHits[T]      `json:"hits"`
+	Aggregations AggregationResults `json:"aggregations,omitempty"`
+	Profile      *Profile           `json:"profile,omitempty"`
+}
+
+type SearchHits[T any] struct {
+	Total    Total          `json:"total"`
+	MaxScore float64        `json:"max_score"`
+	Hits     []SearchHit[T] `json:"hits"`
+}
+
+type SearchHit[T any] struct {
+	Index       string                   `json:"_index"`
+	ID          string                   `json:"_id"`
+	Score       float64                  `json:"_score"`
+	Sort        []interface{}            `json:"sort,omitempty"`
+	Highlight   map[string][]string      `json:"highlight,omitempty"`
+	Explanation *Explanation             `json:"_explanation,omitempty"`
+	Fields      map[string][]interface{} `json:"fields,omitempty"`
+	InnerHits   map[string]InnerHits     `json:"inner_hits,omitempty"`
+	Source      T                        `json:"_source"`
+}
+
+// Sources returns the decoded _source of every hit in order.
+func (h SearchHits[T]) Sources() []T {
+	sources := make([]T, 0, len(h.Hits))
+	for _, hit := range h.Hits {
+		sources = append(sources, hit.Source)
+	}
+
+	return sources
+}
@@ -0,0 +1,8 @@
+package models
+
+// StoredTemplate is a search template stored in the cluster, Source is the mustache template.
+type StoredTemplate struct {
+	ID     string `json:"id"`
+	Lang   string `json:"lang"`
+	Source string `json:"source"`
+}
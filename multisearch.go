@@ -0,0 +1,126 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/odysseia-greek/aristoteles/models"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SearchRequest is a single search of a MultiSearch, Request is a query created with the Builder.
+type SearchRequest struct {
+	Index   string
+	Request map[string]interface{}
+}
+
+// MultiSearchResult holds the outcome of one SearchRequest, either Response or Err is set.
+type MultiSearchResult struct {
+	Response *models.Response
+	Err      error
+}
+
+func (q *QueryImpl) MultiSearch(requests []SearchRequest) ([]MultiSearchResult, error) {
+	return q.MultiSearchContext(context.Background(), requests)
+}
+
+// MultiSearchContext sends all requests to _msearch in one round trip. The results are in the order of requests, a
+// failing search only sets the Err of its own result. The returned error is set when the batch as a whole failed.
+func (q *QueryImpl) MultiSearchContext(ctx context.Context, requests []SearchRequest) (results []MultiSearchResult, err error) {
+	indices := make([]string, 0, len(requests))
+	for _, request := range requests {
+		indices = append(indices, request.Index)
+	}
+
+	ctx, op := q.instrument.begin(ctx, "msearch", strings.Join(indices, ","))
+	defer func() { op.end(err) }()
+
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no search requests to send")
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, request := range requests {
+		if err := encoder.Encode(map[string]interface{}{"index": request.Index}); err != nil {
+			return nil, err
+		}
+
+		query := request.Request
+		if query == nil {
+			query = map[string]interface{}{}
+		}
+		if err := encoder.Encode(query); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := q.es.Msearch(
+		&body,
+		q.es.Msearch.WithContext(ctx),
+	)
+	op.response(res)
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newElasticError(res)
+	}
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var multiSearch struct {
+		Responses []json.RawMessage `json:"responses"`
+	}
+	if err := json.Unmarshal(content, &multiSearch); err != nil {
+		return nil, err
+	}
+
+	if len(multiSearch.Responses) != len(requests) {
+		return nil, fmt.Errorf("sent %d searches but elasticsearch answered %d", len(requests), len(multiSearch.Responses))
+	}
+
+	results = make([]MultiSearchResult, 0, len(requests))
+	for _, item := range multiSearch.Responses {
+		result := parseMultiSearchItem(item)
+		if result.Response != nil {
+			op.result(result.Response.Hits.Total.Value, result.Response.Took, len(result.Response.Hits.Hits))
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func parseMultiSearchItem(item json.RawMessage) MultiSearchResult {
+	indexError, err := models.UnmarshalIndexError(item)
+	if err != nil {
+		return MultiSearchResult{Err: err}
+	}
+
+	if indexError.Error.Type != "" || indexError.Error.Reason != "" || indexError.Status >= http.StatusMultipleChoices {
+		elasticError := &ElasticError{
+			StatusCode: indexError.Status,
+			Status:     fmt.Sprintf("%d %s", indexError.Status, http.StatusText(indexError.Status)),
+		}
+		elasticError.setCause(indexError.Error)
+
+		return MultiSearchResult{Err: elasticError}
+	}
+
+	response, err := models.UnmarshalResponse(item)
+	if err != nil {
+		return MultiSearchResult{Err: err}
+	}
+
+	return MultiSearchResult{Response: &response}
+}
@@ -0,0 +1,91 @@
+package aristoteles
+
+import (
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestQueryClientMultiSearch(t *testing.T) {
+	requests := []SearchRequest{
+		{Index: "herodotos", Request: NewBuilderImpl().MatchQuery("author", "herodotos")},
+		{Index: "sokrates", Request: NewBuilderImpl().MatchAll()},
+		{Index: "herodotos", Request: NewBuilderImpl().MatchQuery("chapter", "2")},
+	}
+
+	t.Run("ResultsInOrder", func(t *testing.T) {
+		file := "multiSearch"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MultiSearch(requests)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(sut))
+
+		assert.Nil(t, sut[0].Err)
+		assert.Equal(t, int64(1), sut[0].Response.Hits.Total.Value)
+		assert.Equal(t, "herodotos", sut[0].Response.Hits.Hits[0].Index)
+
+		assert.Nil(t, sut[1].Response)
+		assert.True(t, IsNotFound(sut[1].Err))
+		var elasticError *ElasticError
+		assert.ErrorAs(t, sut[1].Err, &elasticError)
+		assert.Equal(t, "index_not_found_exception", elasticError.Type)
+		assert.Equal(t, "sokrates", elasticError.Index)
+		assert.Equal(t, "404 Not Found", elasticError.Status)
+
+		assert.Nil(t, sut[2].Err)
+		assert.Equal(t, 0, len(sut[2].Response.Hits.Hits))
+	})
+
+	t.Run("NDJSONBody", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "multiSearch", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"}, WithTransport(transport))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().MultiSearch(requests)
+		assert.Nil(t, err)
+		assert.Equal(t, "/_msearch", transport.Requests()[0].URL.Path)
+
+		lines := strings.Split(strings.TrimSpace(string(transport.Requests()[0].Content)), "\n")
+		assert.Equal(t, 6, len(lines))
+		assert.Equal(t, `{"index":"herodotos"}`, lines[0])
+		assert.Equal(t, `{"index":"sokrates"}`, lines[2])
+		assert.Equal(t, `{"query":{"match_all":{}}}`, lines[3])
+	})
+
+	t.Run("CountMismatch", func(t *testing.T) {
+		file := "multiSearch"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MultiSearch(requests[:1])
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("NoRequests", func(t *testing.T) {
+		file := "multiSearch"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MultiSearch(nil)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		file := "serviceDown"
+		status := 502
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MultiSearch(requests)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+}
@@ -0,0 +1,247 @@
+package aristoteles
+
+import (
+	"context"
+	"fmt"
+	"github.com/elastic/elastic-transport-go/v8/elastictransport"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/odysseia-greek/aristoteles/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures the client created by NewClient.
+type Option func(*settings)
+
+type settings struct {
+	addresses       []string
+	maxRetries      int
+	maxRetriesSet   bool
+	retryOnStatus   []int
+	requestTimeout  time.Duration
+	compress        bool
+	transport       http.RoundTripper
+	transportLogger elastictransport.Logger
+	logger          *slog.Logger
+	quiet           bool
+	serverName      string
+	minTLSVersion   uint16
+	reload          *ReloadConfig
+	reloadCtx       context.Context
+	tracerProvider  trace.TracerProvider
+	propagator      propagation.TextMapPropagator
+	metrics         *Metrics
+	retry           *RetryConfig
+	circuit         *circuitBreaker
+}
+
+func newSettings(opts ...Option) *settings {
+	s := &settings{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithAddresses sets the Elasticsearch nodes to connect to, replacing the Service from models.Config.
+func WithAddresses(addresses ...string) Option {
+	return func(s *settings) {
+		s.addresses = addresses
+	}
+}
+
+// WithMaxRetries sets how often a failed request is retried, 0 disables retries altogether.
+func WithMaxRetries(retries int) Option {
+	return func(s *settings) {
+		s.maxRetries = retries
+		s.maxRetriesSet = true
+	}
+}
+
+// WithRetryOnStatus sets the status codes that are retried, the default is 502, 503 and 504.
+func WithRetryOnStatus(statusCodes ...int) Option {
+	return func(s *settings) {
+		s.retryOnStatus = statusCodes
+	}
+}
+
+// WithRequestTimeout bounds every request, including reading the response body, to the given duration. With WithRetry
+// the timeout applies to each attempt and not to the retried call as a whole.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(s *settings) {
+		s.requestTimeout = timeout
+	}
+}
+
+// WithCompression gzips request bodies.
+func WithCompression() Option {
+	return func(s *settings) {
+		s.compress = true
+	}
+}
+
+// WithTransport replaces the http.RoundTripper used to talk to Elasticsearch.
+// When tls is configured the transport has to be an *http.Transport so the certificates can be added to it.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(s *settings) {
+		s.transport = transport
+	}
+}
+
+// WithTransportLogger logs every request and response, for example with &elastictransport.ColorLogger{Output: os.Stdout}.
+func WithTransportLogger(logger elastictransport.Logger) Option {
+	return func(s *settings) {
+		s.transportLogger = logger
+	}
+}
+
+// WithTLSServerName overrides the name used to verify the certificate elasticsearch presents, useful when connecting
+// through an address that is not in the certificate.
+func WithTLSServerName(serverName string) Option {
+	return func(s *settings) {
+		s.serverName = serverName
+	}
+}
+
+// WithMinTLSVersion sets the minimum tls version accepted, for example tls.VersionTLS13.
+func WithMinTLSVersion(version uint16) Option {
+	return func(s *settings) {
+		s.minTLSVersion = version
+	}
+}
+
+// WithLogger sets the logger used by the client, requests are logged at debug level and failures at warn level.
+// When not set slog.Default() is used.
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *settings) {
+		s.logger = logger
+	}
+}
+
+// WithQuiet silences the startup banner and the progress logged while waiting for the cluster to become healthy.
+func WithQuiet() Option {
+	return func(s *settings) {
+		s.quiet = true
+	}
+}
+
+// WithTracerProvider creates a span for every operation, with the span from the context of the caller as parent.
+// The trace context is propagated to elasticsearch through the headers of the request.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(s *settings) {
+		s.tracerProvider = provider
+	}
+}
+
+// WithPropagator sets the propagator used to add the trace context to requests, the default is otel.GetTextMapPropagator().
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(s *settings) {
+		s.propagator = propagator
+	}
+}
+
+func (s *settings) log() *slog.Logger {
+	if s.logger == nil {
+		return slog.Default()
+	}
+
+	return s.logger
+}
+
+// configLog is the logger for reading the configuration, nothing is logged when quiet.
+func (s *settings) configLog() *slog.Logger {
+	if s.quiet {
+		return slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	return s.log()
+}
+
+func (s *settings) elasticConfig(config models.Config) elasticsearch.Config {
+	addresses := s.addresses
+	if len(addresses) == 0 {
+		addresses = []string{config.Service}
+	}
+
+	cfg := elasticsearch.Config{
+		Username:            config.Username,
+		Password:            config.Password,
+		APIKey:              config.APIKey,
+		ServiceToken:        config.ServiceToken,
+		Addresses:           addresses,
+		RetryOnStatus:       s.retryOnStatus,
+		CompressRequestBody: s.compress,
+		Logger:              s.transportLogger,
+		Transport:           s.wrapTransport(s.transport),
+	}
+
+	if config.BearerToken != "" {
+		cfg.Header = http.Header{"Authorization": []string{fmt.Sprintf("Bearer %s", config.BearerToken)}}
+	}
+
+	if s.maxRetriesSet {
+		cfg.MaxRetries = s.maxRetries
+		cfg.DisableRetry = s.maxRetries <= 0
+	}
+
+	if s.retry != nil {
+		cfg.DisableRetry = true
+	}
+
+	return cfg
+}
+
+// httpTransport returns a clone of the configured transport, or of http.DefaultTransport when none was set,
+// so TLS settings can be applied without changing the original.
+func (s *settings) httpTransport() (*http.Transport, error) {
+	if s.transport == nil {
+		return http.DefaultTransport.(*http.Transport).Clone(), nil
+	}
+
+	tp, ok := s.transport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("cannot configure tls on transport of type %T", s.transport)
+	}
+
+	return tp.Clone(), nil
+}
+
+// wrapTransport adds the transports for the configured options around transport. Every retry passes the circuit
+// breaker and gets its own timeout.
+func (s *settings) wrapTransport(transport http.RoundTripper) http.RoundTripper {
+	if s.requestTimeout <= 0 && s.tracerProvider == nil && s.retry == nil && s.circuit == nil {
+		return transport
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+
+	if s.tracerProvider != nil {
+		propagator := s.propagator
+		if propagator == nil {
+			propagator = otel.GetTextMapPropagator()
+		}
+		transport = &propagationTransport{next: transport, propagator: propagator}
+	}
+
+	if s.requestTimeout > 0 {
+		transport = &timeoutTransport{next: transport, timeout: s.requestTimeout}
+	}
+
+	if s.circuit != nil {
+		transport = &circuitTransport{next: transport, circuit: s.circuit}
+	}
+
+	if s.retry != nil {
+		transport = &retryTransport{next: transport, config: *s.retry}
+	}
+
+	return transport
+}
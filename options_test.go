@@ -0,0 +1,200 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClientOptions(t *testing.T) {
+	index := "test"
+	config := models.Config{
+		Service:     "http://localhost:9200",
+		Username:    "",
+		Password:    "",
+		ElasticCERT: "",
+	}
+
+	t.Run("Addresses", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		testClient, err := NewClient(config,
+			WithAddresses("http://elastic-0:9200"),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.NotNil(t, sut)
+		assert.Equal(t, 1, len(transport.Requests()))
+		assert.Equal(t, "elastic-0:9200", transport.Requests()[0].URL.Host)
+	})
+
+	t.Run("MaxRetries", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "serviceDown", StatusCode: 503})
+		testClient, err := NewClient(config,
+			WithMaxRetries(2),
+			WithRetryOnStatus(http.StatusServiceUnavailable),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+		assert.Equal(t, 3, len(transport.Requests()))
+	})
+
+	t.Run("RetriesDisabled", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "serviceDown", StatusCode: 502})
+		testClient, err := NewClient(config,
+			WithMaxRetries(0),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+		assert.Equal(t, 1, len(transport.Requests()))
+	})
+
+	t.Run("Compression", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		testClient, err := NewClient(config,
+			WithCompression(),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(transport.Requests()))
+		assert.Equal(t, "gzip", transport.Requests()[0].Header.Get("Content-Encoding"))
+	})
+
+	t.Run("RequestTimeout", func(t *testing.T) {
+		mockTrans := MockTransport{}
+		mockTrans.RoundTripFn = func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}
+
+		testClient, err := NewClient(config,
+			WithMaxRetries(0),
+			WithRequestTimeout(10*time.Millisecond),
+			WithTransport(&mockTrans),
+		)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("TLSWithCustomRoundTripper", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		tlsConfig := config
+		tlsConfig.ElasticCERT = "cert"
+
+		testClient, err := NewClient(tlsConfig, WithTransport(transport))
+		assert.NotNil(t, err)
+		assert.Nil(t, testClient)
+	})
+}
+
+func TestClientAuthentication(t *testing.T) {
+	index := "test"
+
+	t.Run("BasicAuth", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		config := models.Config{
+			Service:  "http://localhost:9200",
+			Username: "elastic",
+			Password: "odysseia",
+		}
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+
+		username, password, ok := transport.Requests()[0].BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "elastic", username)
+		assert.Equal(t, "odysseia", password)
+	})
+
+	t.Run("ApiKey", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		config := models.Config{
+			Service:  "http://localhost:9200",
+			Username: "elastic",
+			Password: "odysseia",
+			APIKey:   "apikey",
+		}
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "APIKey apikey", transport.Requests()[0].Header.Get("Authorization"))
+	})
+
+	t.Run("ServiceToken", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		config := models.Config{
+			Service:      "http://localhost:9200",
+			ServiceToken: "servicetoken",
+		}
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "Bearer servicetoken", transport.Requests()[0].Header.Get("Authorization"))
+	})
+
+	t.Run("BearerTokenTakesPrecedence", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		config := models.Config{
+			Service:     "http://localhost:9200",
+			APIKey:      "apikey",
+			BearerToken: "bearertoken",
+		}
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Bearer bearertoken"}, transport.Requests()[0].Header.Values("Authorization"))
+	})
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	t.Run("DeadlineHeldUntilBodyClosed", func(t *testing.T) {
+		var reqCtx context.Context
+		mockTrans := MockTransport{}
+		mockTrans.RoundTripFn = func(req *http.Request) (*http.Response, error) {
+			reqCtx = req.Context()
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader([]byte("{}")))}, nil
+		}
+
+		sut := &timeoutTransport{next: &mockTrans, timeout: time.Minute}
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:9200", nil)
+		assert.Nil(t, err)
+
+		res, err := sut.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Nil(t, reqCtx.Err())
+
+		assert.Nil(t, res.Body.Close())
+		assert.ErrorIs(t, reqCtx.Err(), context.Canceled)
+	})
+}
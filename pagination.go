@@ -0,0 +1,73 @@
+package aristoteles
+
+import (
+	"context"
+	"fmt"
+	"github.com/odysseia-greek/aristoteles/models"
+)
+
+// WithPage returns page number page of size hits, page numbers start at 1. It replaces any from and size of the
+// request. Elasticsearch refuses pages beyond its max_result_window of 10000 hits, use Iterate to go deeper.
+func WithPage(page, size int) SearchOption {
+	return func(body map[string]interface{}) {
+		if page < 1 {
+			page = 1
+		}
+		body["from"] = (page - 1) * size
+		body["size"] = size
+	}
+}
+
+func (q *QueryImpl) MatchPage(index string, request map[string]interface{}, page, size int, opts ...SearchOption) (*models.Page[models.Hit], error) {
+	return q.MatchPageContext(context.Background(), index, request, page, size, opts...)
+}
+
+// MatchPageContext searches for page number page of size hits, opts are applied before the page so they cannot
+// change it.
+func (q *QueryImpl) MatchPageContext(ctx context.Context, index string, request map[string]interface{}, page, size int, opts ...SearchOption) (*models.Page[models.Hit], error) {
+	if err := validatePage(page, size); err != nil {
+		return nil, err
+	}
+
+	response, err := q.MatchWithOptionsContext(ctx, index, request, withPage(opts, page, size)...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewPage(response.Hits.Hits, response.Hits.Total.Value, page, size)
+	return &result, nil
+}
+
+// SearchPage is MatchPage with the _source of every hit decoded into T.
+func SearchPage[T any](q Query, index string, request map[string]interface{}, page, size int, opts ...SearchOption) (*models.Page[T], error) {
+	return SearchPageContext[T](context.Background(), q, index, request, page, size, opts...)
+}
+
+func SearchPageContext[T any](ctx context.Context, q Query, index string, request map[string]interface{}, page, size int, opts ...SearchOption) (*models.Page[T], error) {
+	if err := validatePage(page, size); err != nil {
+		return nil, err
+	}
+
+	response, err := SearchContext[T](ctx, q, index, request, withPage(opts, page, size)...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewPage(response.Hits.Sources(), response.Hits.Total.Value, page, size)
+	return &result, nil
+}
+
+// withPage appends the page to a copy of opts so it is applied last.
+func withPage(opts []SearchOption, page, size int) []SearchOption {
+	paged := make([]SearchOption, 0, len(opts)+1)
+	paged = append(paged, opts...)
+	return append(paged, WithPage(page, size))
+}
+
+func validatePage(page, size int) error {
+	if page < 1 || size < 1 {
+		return fmt.Errorf("page starts at 1 and size must be positive, got page %d and size %d", page, size)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package aristoteles
+
+import (
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQueryClientPagination(t *testing.T) {
+	index := "herodotos"
+	config := models.Config{
+		Service: "http://localhost:9200",
+	}
+
+	t.Run("MatchPage", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "matchPage", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery("author", "herodotos")
+		sut, err := testClient.Query().MatchPage(index, body, 2, 2, WithSort(SortField{Field: "chapter", Order: SortAscending}))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(sut.Items))
+		assert.Equal(t, int64(23), sut.Total)
+		assert.Equal(t, 2, sut.Page)
+		assert.Equal(t, 2, sut.PageSize)
+		assert.True(t, sut.HasMore)
+		assert.Equal(t, 12, sut.TotalPages())
+
+		requests := transport.Requests()
+		assert.Equal(t, float64(2), requests[0].JSON()["from"])
+		assert.Equal(t, float64(2), requests[0].JSON()["size"])
+		assert.Contains(t, requests[0].JSON(), "sort")
+		assert.NotContains(t, body, "from")
+	})
+
+	t.Run("OverridesBuilderSize", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "matchPage", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MultiMatchWithGram("λόγ", "greek")
+		_, err = testClient.Query().MatchWithOptions(index, body, WithPage(3, 50))
+		assert.Nil(t, err)
+		assert.Equal(t, float64(100), transport.Requests()[0].JSON()["from"])
+		assert.Equal(t, float64(50), transport.Requests()[0].JSON()["size"])
+	})
+
+	t.Run("LastPage", func(t *testing.T) {
+		file := "matchPage"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchPage(index, testClient.Builder().MatchAll(), 12, 2)
+		assert.Nil(t, err)
+		assert.False(t, sut.HasMore)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		file := "matchEmptyHits"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchPage(index, testClient.Builder().MatchAll(), 1, 10)
+		assert.Nil(t, err)
+		assert.NotNil(t, sut.Items)
+		assert.Equal(t, 0, len(sut.Items))
+		assert.False(t, sut.HasMore)
+	})
+
+	t.Run("InvalidPage", func(t *testing.T) {
+		file := "matchPage"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchPage(index, testClient.Builder().MatchAll(), 0, 10)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+
+		sut, err = testClient.Query().MatchPage(index, testClient.Builder().MatchAll(), 1, 0)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		file := "matchPage"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		type chapter struct {
+			Author  string `json:"author"`
+			Chapter int    `json:"chapter"`
+		}
+
+		sut, err := SearchPage[chapter](testClient.Query(), index, testClient.Builder().MatchAll(), 1, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 4, sut.Items[1].Chapter)
+		assert.True(t, sut.HasMore)
+	})
+
+	t.Run("TypedWithOptions", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "matchPage", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		type chapter struct {
+			Chapter int `json:"chapter"`
+		}
+
+		sort := WithSort(SortField{Field: "chapter", Order: SortAscending})
+		sut, err := SearchPage[chapter](testClient.Query(), index, testClient.Builder().MatchAll(), 1, 2, sort, WithSourceIncludes("chapter"), WithPage(5, 10))
+		assert.Nil(t, err)
+		assert.Equal(t, 4, sut.Items[1].Chapter)
+
+		sent := transport.Requests()[0].JSON()
+		assert.Equal(t, float64(0), sent["from"])
+		assert.Equal(t, float64(2), sent["size"])
+		assert.Contains(t, sent, "sort")
+		assert.Equal(t, map[string]interface{}{"includes": []interface{}{"chapter"}}, sent["_source"])
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		file := "serviceDown"
+		status := 502
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchPage(index, testClient.Builder().MatchAll(), 1, 10)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+}
@@ -0,0 +1,322 @@
+package aristoteles
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/odysseia-greek/aristoteles/models"
+	"gopkg.in/yaml.v3"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ProfileLocal             = "local"
+	ProfileTest              = "test"
+	ProfileCluster           = "cluster"
+	EnvElasticProfile        = "ELASTIC_SEARCH_PROFILE"
+	EnvElasticCAPath         = "ELASTIC_SEARCH_CA_PATH"
+	EnvElasticIndexPrefix    = "ELASTIC_SEARCH_INDEX_PREFIX"
+	localCertPathFromHomeDir = ".odysseia/current/elastic-certificate.pem"
+	testCertPath             = "eratosthenes/elastic-test-cert.pem"
+)
+
+// ConfigFile is the layout of a yaml or json file holding named connection profiles.
+type ConfigFile struct {
+	Profiles map[string]Profile `json:"profiles" yaml:"profiles"`
+}
+
+type Profile struct {
+	Name        string      `json:"-" yaml:"-"`
+	Services    []string    `json:"services" yaml:"services"`
+	Auth        ProfileAuth `json:"auth" yaml:"auth"`
+	TLS         ProfileTLS  `json:"tls" yaml:"tls"`
+	IndexPrefix string      `json:"indexPrefix" yaml:"indexPrefix"`
+}
+
+type ProfileAuth struct {
+	Username     string `json:"username" yaml:"username"`
+	Password     string `json:"password" yaml:"password"`
+	APIKey       string `json:"apiKey" yaml:"apiKey"`
+	BearerToken  string `json:"bearerToken" yaml:"bearerToken"`
+	ServiceToken string `json:"serviceToken" yaml:"serviceToken"`
+}
+
+// ProfileTLS points to PEM files, a leading ~/ is expanded to the home directory.
+type ProfileTLS struct {
+	CAPath     string `json:"caPath" yaml:"caPath"`
+	CertPath   string `json:"certPath" yaml:"certPath"`
+	KeyPath    string `json:"keyPath" yaml:"keyPath"`
+	ServerName string `json:"serverName" yaml:"serverName"`
+	MinVersion string `json:"minVersion" yaml:"minVersion"`
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// DefaultProfiles returns the profiles odysseia runs with when no file is supplied: a local cluster with the
+// certificate in the home directory, the test certificate from eratosthenes and the certificate mounted in a pod.
+func DefaultProfiles() *ConfigFile {
+	return &ConfigFile{
+		Profiles: map[string]Profile{
+			ProfileLocal: {
+				Services: []string{elasticServiceDefaultTlS},
+				Auth:     ProfileAuth{Username: elasticUsernameDefault, Password: elasticPasswordDefault},
+				TLS:      ProfileTLS{CAPath: filepath.Join("~", localCertPathFromHomeDir)},
+			},
+			ProfileTest: {
+				Services: []string{elasticServiceDefaultTlS},
+				Auth:     ProfileAuth{Username: elasticUsernameDefault, Password: elasticPasswordDefault},
+				TLS:      ProfileTLS{CAPath: testCertPath},
+			},
+			ProfileCluster: {
+				Services: []string{elasticServiceDefaultTlS},
+				Auth:     ProfileAuth{Username: elasticUsernameDefault, Password: elasticPasswordDefault},
+				TLS:      ProfileTLS{CAPath: certPathInPod},
+			},
+		},
+	}
+}
+
+// LoadConfigFile reads a file with profiles, the format is picked from the extension (.yaml, .yml or .json).
+func LoadConfigFile(path string) (*ConfigFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var configFile ConfigFile
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &configFile)
+	case ".json":
+		err = json.Unmarshal(content, &configFile)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q, use .yaml, .yml or .json", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return &configFile, nil
+}
+
+// Profile returns the named profile, falling back to the profile in ELASTIC_SEARCH_PROFILE when name is empty.
+func (c *ConfigFile) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv(EnvElasticProfile)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no profile selected, set %s or pass a profile name", EnvElasticProfile)
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found", name)
+	}
+	profile.Name = name
+
+	return &profile, nil
+}
+
+// LoadProfile reads the profile from the file at path, applies the environment overrides and validates the result.
+func LoadProfile(path, name string) (*Profile, error) {
+	configFile, err := LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := configFile.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.ApplyEnv()
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+// ApplyEnv overrides the profile with every environment variable that is set. ELASTIC_SEARCH_SERVICE may hold a comma
+// separated list of services.
+func (p *Profile) ApplyEnv() {
+	if services := os.Getenv(EnvElasticService); services != "" {
+		p.Services = nil
+		for _, service := range strings.Split(services, ",") {
+			p.Services = append(p.Services, strings.TrimSpace(service))
+		}
+	}
+
+	overrides := map[string]*string{
+		EnvElasticUser:         &p.Auth.Username,
+		EnvElasticPassword:     &p.Auth.Password,
+		EnvElasticApiKey:       &p.Auth.APIKey,
+		EnvElasticBearerToken:  &p.Auth.BearerToken,
+		EnvElasticServiceToken: &p.Auth.ServiceToken,
+		EnvElasticCAPath:       &p.TLS.CAPath,
+		EnvElasticIndexPrefix:  &p.IndexPrefix,
+	}
+
+	for env, target := range overrides {
+		if value := os.Getenv(env); value != "" {
+			*target = value
+		}
+	}
+}
+
+// Validate reports every missing or inconsistent field at once.
+func (p *Profile) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("profile %q: %s", p.Name, fmt.Sprintf(format, args...)))
+	}
+
+	if len(p.Services) == 0 {
+		invalid("no services configured")
+	}
+
+	for _, service := range p.Services {
+		u, err := url.Parse(strings.TrimSpace(service))
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			invalid("service %q is not a valid http(s) url", service)
+			continue
+		}
+
+		if u.Scheme == "http" && p.usesTLS() {
+			invalid("service %q uses http while tls is configured", service)
+		}
+	}
+
+	if p.Auth.Username != "" && p.Auth.Password == "" {
+		invalid("auth.username is set without auth.password")
+	}
+	if p.Auth.Password != "" && p.Auth.Username == "" {
+		invalid("auth.password is set without auth.username")
+	}
+
+	if (p.TLS.CertPath == "") != (p.TLS.KeyPath == "") {
+		invalid("tls.certPath and tls.keyPath have to be set together")
+	}
+
+	for _, file := range p.TLS.files(nil) {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(expandHome(file.path)); err != nil {
+			invalid("%s: %s", file.field, err)
+		}
+	}
+
+	if p.TLS.MinVersion != "" {
+		if _, ok := tlsVersions[p.TLS.MinVersion]; !ok {
+			invalid("tls.minVersion %q is not one of 1.0, 1.1, 1.2 or 1.3", p.TLS.MinVersion)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Config reads the certificate files of the profile into a models.Config.
+func (p *Profile) Config() (models.Config, error) {
+	config := models.Config{
+		Username:     p.Auth.Username,
+		Password:     p.Auth.Password,
+		APIKey:       p.Auth.APIKey,
+		BearerToken:  p.Auth.BearerToken,
+		ServiceToken: p.Auth.ServiceToken,
+	}
+
+	if len(p.Services) > 0 {
+		config.Service = p.Services[0]
+	}
+
+	for _, file := range p.TLS.files(&config) {
+		if file.path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(expandHome(file.path))
+		if err != nil {
+			return config, err
+		}
+		*file.target = string(content)
+	}
+
+	return config, nil
+}
+
+// Options returns the client options the profile sets on top of its models.Config.
+func (p *Profile) Options() []Option {
+	var opts []Option
+	if len(p.Services) > 1 {
+		opts = append(opts, WithAddresses(p.Services...))
+	}
+	if p.TLS.ServerName != "" {
+		opts = append(opts, WithTLSServerName(p.TLS.ServerName))
+	}
+	if version, ok := tlsVersions[p.TLS.MinVersion]; ok {
+		opts = append(opts, WithMinTLSVersion(version))
+	}
+
+	return opts
+}
+
+// IndexName prefixes index with the index prefix of the profile.
+func (p *Profile) IndexName(index string) string {
+	return p.IndexPrefix + index
+}
+
+type profileFile struct {
+	field  string
+	path   string
+	target *string
+}
+
+// files lists the PEM files of the profile with the field of config they are read into, config may be nil.
+func (t ProfileTLS) files(config *models.Config) []profileFile {
+	if config == nil {
+		config = &models.Config{}
+	}
+
+	return []profileFile{
+		{"tls.caPath", t.CAPath, &config.ElasticCERT},
+		{"tls.certPath", t.CertPath, &config.ClientCERT},
+		{"tls.keyPath", t.KeyPath, &config.ClientKey},
+	}
+}
+
+func (p *Profile) usesTLS() bool {
+	return p.TLS.CAPath != "" || p.TLS.CertPath != "" || p.TLS.ServerName != "" || p.TLS.MinVersion != ""
+}
+
+// NewClientFromProfile creates a client from a loaded profile, opts are applied after the options of the profile.
+func NewClientFromProfile(profile *Profile, opts ...Option) (Client, error) {
+	config, err := profile.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(config, append(profile.Options(), opts...)...)
+}
+
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		return path
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+
+	return filepath.Join(homeDir, path[2:])
+}
@@ -0,0 +1,109 @@
+package aristoteles
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigProfiles(t *testing.T) {
+	profilesFile := filepath.Join("eratosthenes", "profiles.yaml")
+
+	t.Run("LoadYaml", func(t *testing.T) {
+		sut, err := LoadProfile(profilesFile, ProfileLocal)
+		assert.Nil(t, err)
+		assert.Equal(t, ProfileLocal, sut.Name)
+		assert.Equal(t, []string{"https://localhost:9200"}, sut.Services)
+		assert.Equal(t, "local-dictionary", sut.IndexName("dictionary"))
+
+		config, err := sut.Config()
+		assert.Nil(t, err)
+		assert.Equal(t, "https://localhost:9200", config.Service)
+		assert.Equal(t, "elastic", config.Username)
+		assert.Contains(t, config.ElasticCERT, "BEGIN CERTIFICATE")
+		assert.Equal(t, 2, len(sut.Options()))
+	})
+
+	t.Run("LoadJson", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "profiles.json")
+		content := `{"profiles":{"test":{"services":["http://localhost:9200"],"auth":{"serviceToken":"token"},"indexPrefix":"test-"}}}`
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+
+		sut, err := LoadProfile(path, ProfileTest)
+		assert.Nil(t, err)
+		assert.Equal(t, "token", sut.Auth.ServiceToken)
+		assert.Equal(t, "test-text", sut.IndexName("text"))
+	})
+
+	t.Run("ProfileFromEnv", func(t *testing.T) {
+		t.Setenv(EnvElasticProfile, ProfileCluster)
+
+		sut, err := LoadProfile(profilesFile, "")
+		assert.Nil(t, err)
+		assert.Equal(t, ProfileCluster, sut.Name)
+		assert.Equal(t, 2, len(sut.Services))
+	})
+
+	t.Run("EnvOverrides", func(t *testing.T) {
+		t.Setenv(EnvElasticService, "https://elastic-0:9200, https://elastic-1:9200")
+		t.Setenv(EnvElasticPassword, "fromEnv")
+		t.Setenv(EnvElasticIndexPrefix, "env-")
+
+		sut, err := LoadProfile(profilesFile, ProfileLocal)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"https://elastic-0:9200", "https://elastic-1:9200"}, sut.Services)
+		assert.Equal(t, "fromEnv", sut.Auth.Password)
+		assert.Equal(t, "env-quiz", sut.IndexName("quiz"))
+	})
+
+	t.Run("ValidationReportsEverything", func(t *testing.T) {
+		sut, err := LoadProfile(profilesFile, "broken")
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+
+		expected := []string{
+			`service "http://localhost:9200" uses http while tls is configured`,
+			`service "localhost" is not a valid http(s) url`,
+			"auth.username is set without auth.password",
+			"tls.certPath and tls.keyPath have to be set together",
+			"tls.caPath",
+			"tls.certPath: stat",
+			`tls.minVersion "1.4"`,
+		}
+		for _, message := range expected {
+			assert.Contains(t, err.Error(), message)
+		}
+	})
+
+	t.Run("UnknownProfile", func(t *testing.T) {
+		sut, err := LoadProfile(profilesFile, "athens")
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("UnsupportedExtension", func(t *testing.T) {
+		sut, err := LoadConfigFile(filepath.Join("eratosthenes", "elastic-test-cert.pem"))
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("DefaultTestProfileMatchesGetCert", func(t *testing.T) {
+		profile, err := DefaultProfiles().Profile(ProfileTest)
+		assert.Nil(t, err)
+
+		config, err := profile.Config()
+		assert.Nil(t, err)
+		assert.Equal(t, string(GetCert("TEST", true)), config.ElasticCERT)
+		assert.NotEmpty(t, config.ElasticCERT)
+	})
+
+	t.Run("NewClientFromProfile", func(t *testing.T) {
+		profile, err := LoadProfile(profilesFile, ProfileCluster)
+		assert.Nil(t, err)
+
+		sut, err := NewClientFromProfile(profile, WithQuiet())
+		assert.Nil(t, err)
+		assert.NotNil(t, sut)
+	})
+}
@@ -2,16 +2,19 @@ package aristoteles
 
 import (
 	"context"
-	"fmt"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
 	"github.com/odysseia-greek/aristoteles/models"
+	"io"
 	"io/ioutil"
+	"log/slog"
+	"strings"
 	"time"
 )
 
 type QueryImpl struct {
-	es *elasticsearch.Client
+	es         *elasticsearch.Client
+	instrument *instrument
 }
 
 func NewQueryImpl(suppliedClient *elasticsearch.Client) (*QueryImpl, error) {
@@ -19,50 +22,35 @@ func NewQueryImpl(suppliedClient *elasticsearch.Client) (*QueryImpl, error) {
 }
 
 func (q *QueryImpl) Match(index string, request map[string]interface{}) (*models.Response, error) {
-	query, err := toBuffer(request)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := q.es.Search(
-		q.es.Search.WithContext(context.Background()),
-		q.es.Search.WithIndex(index),
-		q.es.Search.WithBody(&query),
-		q.es.Search.WithTrackTotalHits(true),
-		q.es.Search.WithPretty(),
-	)
-
-	if err != nil {
-		return nil, err
-	}
+	return q.MatchContext(context.Background(), index, request)
+}
 
-	return q.parseResponse(res)
+func (q *QueryImpl) MatchContext(ctx context.Context, index string, request map[string]interface{}) (*models.Response, error) {
+	return q.MatchWithOptionsContext(ctx, index, request)
 }
 
 func (q *QueryImpl) MatchWithSort(index, direction, sortField string, size int, request map[string]interface{}) (*models.Response, error) {
-	query, err := toBuffer(request)
-	if err != nil {
-		return nil, err
-	}
+	return q.MatchWithSortContext(context.Background(), index, direction, sortField, size, request)
+}
 
-	res, err := q.es.Search(
-		q.es.Search.WithContext(context.Background()),
-		q.es.Search.WithIndex(index),
-		q.es.Search.WithBody(&query),
-		q.es.Search.WithSize(size),
-		q.es.Search.WithTrackTotalHits(true),
-		q.es.Search.WithSort(fmt.Sprintf("%s:%s", sortField, direction), "mode:max"),
-		q.es.Search.WithPretty(),
+// MatchWithSortContext sorts on a single field, use MatchWithOptionsContext with WithSort to sort on several fields.
+func (q *QueryImpl) MatchWithSortContext(ctx context.Context, index, direction, sortField string, size int, request map[string]interface{}) (*models.Response, error) {
+	return q.MatchWithOptionsContext(ctx, index, request,
+		WithSort(SortField{Field: sortField, Order: direction, Mode: "max"}),
+		func(body map[string]interface{}) { body["size"] = size },
 	)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return q.parseResponse(res)
 }
 
+// Deprecated: MatchWithScroll keeps every hit in memory and holds a scroll context open, use Iterate instead.
 func (q *QueryImpl) MatchWithScroll(index string, request map[string]interface{}) (*models.Response, error) {
+	return q.MatchWithScrollContext(context.Background(), index, request)
+}
+
+// Deprecated: use Iterate.
+func (q *QueryImpl) MatchWithScrollContext(ctx context.Context, index string, request map[string]interface{}) (result *models.Response, err error) {
+	ctx, op := q.instrument.begin(ctx, "scroll", index)
+	defer func() { op.end(err) }()
+
 	var elasticResult models.Response
 
 	query, err := toBuffer(request)
@@ -71,7 +59,7 @@ func (q *QueryImpl) MatchWithScroll(index string, request map[string]interface{}
 	}
 
 	res, err := q.es.Search(
-		q.es.Search.WithContext(context.Background()),
+		q.es.Search.WithContext(ctx),
 		q.es.Search.WithIndex(index),
 		q.es.Search.WithBody(&query),
 		q.es.Search.WithSize(10),
@@ -79,6 +67,7 @@ func (q *QueryImpl) MatchWithScroll(index string, request map[string]interface{}
 		q.es.Search.WithTrackTotalHits(true),
 		q.es.Search.WithPretty(),
 	)
+	op.response(res)
 
 	if err != nil {
 		return nil, err
@@ -90,6 +79,10 @@ func (q *QueryImpl) MatchWithScroll(index string, request map[string]interface{}
 	}
 
 	scrollID := firstResponse.ScrollId
+	defer q.clearScroll(ctx, &scrollID)
+
+	op.result(firstResponse.Hits.Total.Value, firstResponse.Took, len(firstResponse.Hits.Hits))
+	op.page()
 
 	for _, hit := range firstResponse.Hits.Hits {
 		elasticResult.Hits.Hits = append(elasticResult.Hits.Hits, hit)
@@ -100,21 +93,26 @@ func (q *QueryImpl) MatchWithScroll(index string, request map[string]interface{}
 	}
 
 	for {
-		scrollRes, err := q.es.Scroll(q.es.Scroll.WithScrollID(scrollID), q.es.Scroll.WithScroll(5*time.Second))
+		scrollRes, err := q.es.Scroll(
+			q.es.Scroll.WithContext(ctx),
+			q.es.Scroll.WithScrollID(scrollID),
+			q.es.Scroll.WithScroll(5*time.Second),
+		)
+		op.response(scrollRes)
 		if err != nil {
 			return nil, err
 		}
-		defer scrollRes.Body.Close()
-
-		if scrollRes.IsError() {
-			return nil, fmt.Errorf("elasticSearch returned an error: %s", scrollRes.Status())
-		}
 
-		scrollBody, _ := ioutil.ReadAll(scrollRes.Body)
-		scrollResponse, err := models.UnmarshalResponse(scrollBody)
+		scrollResponse, err := q.parseResponse(scrollRes)
 		if err != nil {
 			return nil, err
 		}
+		op.result(scrollResponse.Hits.Total.Value, scrollResponse.Took, len(scrollResponse.Hits.Hits))
+		op.page()
+
+		if scrollResponse.ScrollId != "" {
+			scrollID = scrollResponse.ScrollId
+		}
 
 		if len(scrollResponse.Hits.Hits) == 0 {
 			break
@@ -127,19 +125,51 @@ func (q *QueryImpl) MatchWithScroll(index string, request map[string]interface{}
 	return &elasticResult, nil
 }
 
+// clearScroll frees the search context of a scroll, it runs after ctx is cancelled as well.
+func (q *QueryImpl) clearScroll(ctx context.Context, scrollID *string) {
+	if *scrollID == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	res, err := q.es.ClearScroll(
+		q.es.ClearScroll.WithContext(ctx),
+		q.es.ClearScroll.WithScrollID(*scrollID),
+	)
+	if err != nil {
+		q.instrument.log().Warn("clearing scroll failed", slog.String("error", err.Error()))
+		return
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		q.instrument.log().Warn("clearing scroll failed", slog.String("error", newElasticError(res).Error()))
+	}
+}
+
 func (q *QueryImpl) MatchAggregate(index string, request map[string]interface{}) (*models.Aggregations, error) {
+	return q.MatchAggregateContext(context.Background(), index, request)
+}
+
+func (q *QueryImpl) MatchAggregateContext(ctx context.Context, index string, request map[string]interface{}) (result *models.Aggregations, err error) {
+	ctx, op := q.instrument.begin(ctx, "aggregate", index)
+	defer func() { op.end(err) }()
+
 	query, err := toBuffer(request)
 	if err != nil {
 		return nil, err
 	}
 
 	res, err := q.es.Search(
-		q.es.Search.WithContext(context.Background()),
+		q.es.Search.WithContext(ctx),
 		q.es.Search.WithIndex(index),
 		q.es.Search.WithBody(&query),
 		q.es.Search.WithTrackTotalHits(true),
 		q.es.Search.WithPretty(),
 	)
+	op.response(res)
 
 	if err != nil {
 		return nil, err
@@ -148,11 +178,93 @@ func (q *QueryImpl) MatchAggregate(index string, request map[string]interface{})
 	return q.parseAggregate(res)
 }
 
+func (q *QueryImpl) Count(index string, request map[string]interface{}) (int64, error) {
+	return q.CountContext(context.Background(), index, request)
+}
+
+// CountContext returns the exact number of documents matching request without fetching them. Index may be a comma
+// separated list of indices. Only the query of request is sent, size, sort and other search options are dropped.
+func (q *QueryImpl) CountContext(ctx context.Context, index string, request map[string]interface{}) (count int64, err error) {
+	ctx, op := q.instrument.begin(ctx, "count", index)
+	defer func() { op.end(err) }()
+
+	countRequest := map[string]interface{}{}
+	if query, ok := request["query"]; ok {
+		countRequest["query"] = query
+	}
+
+	query, err := toBuffer(countRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := q.es.Count(
+		q.es.Count.WithContext(ctx),
+		q.es.Count.WithIndex(strings.Split(index, ",")...),
+		q.es.Count.WithBody(&query),
+	)
+	op.response(res)
+
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, newElasticError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := models.UnmarshalCountResponse(body)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.Count, nil
+}
+
+func (q *QueryImpl) Suggest(index string, request map[string]interface{}) (map[string][]models.Suggestion, error) {
+	return q.SuggestContext(context.Background(), index, request)
+}
+
+// SuggestContext runs the suggesters in request and returns their suggestions by name.
+func (q *QueryImpl) SuggestContext(ctx context.Context, index string, request map[string]interface{}) (suggestions map[string][]models.Suggestion, err error) {
+	ctx, op := q.instrument.begin(ctx, "suggest", index)
+	defer func() { op.end(err) }()
+
+	query, err := toBuffer(request)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := q.es.Search(
+		q.es.Search.WithContext(ctx),
+		q.es.Search.WithIndex(index),
+		q.es.Search.WithBody(&query),
+	)
+	op.response(res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := q.parseResponse(res)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Suggest, nil
+}
+
 func (q *QueryImpl) parseResponse(res *esapi.Response) (*models.Response, error) {
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return nil, newElasticError(res)
 	}
 
 	body, _ := ioutil.ReadAll(res.Body)
@@ -168,7 +280,7 @@ func (q *QueryImpl) parseAggregate(res *esapi.Response) (*models.Aggregations, e
 	defer res.Body.Close()
 
 	if res.IsError() {
-		return nil, fmt.Errorf("%s: %s", errorMessage, res.Status())
+		return nil, newElasticError(res)
 	}
 
 	body, _ := ioutil.ReadAll(res.Body)
@@ -1,10 +1,15 @@
 package aristoteles
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/odysseia-greek/aristoteles/models"
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestQueryClientMatch(t *testing.T) {
@@ -27,6 +32,56 @@ func TestQueryClientMatch(t *testing.T) {
 		assert.Equal(t, expected, sut.Hits.Total.Value)
 	})
 
+	t.Run("Highlight", func(t *testing.T) {
+		file := "matchHighlight"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().Highlight(testClient.Builder().MatchQuery("greek", "ἱστορίης"), HighlightConfig{Fields: []string{"greek"}})
+		sut, err := testClient.Query().Match(index, body)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Ἡροδότου Ἁλικαρνησσέος <mark>ἱστορίης</mark> ἀπόδεξις ἥδε"}, sut.Hits.Hits[0].Highlight["greek"])
+	})
+
+	t.Run("MatchContextPass", func(t *testing.T) {
+		file := "match"
+		status := 200
+		expected := int64(1)
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		sut, err := testClient.Query().MatchContext(ctx, index, body)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, sut.Hits.Total.Value)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		config := models.Config{
+			Service:     "http://localhost:9200",
+			Username:    "",
+			Password:    "",
+			ElasticCERT: "",
+		}
+		testClient, err := NewClient(config)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		sut, err := testClient.Query().MatchContext(ctx, index, body)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		file := "serviceDown"
 		status := 502
@@ -107,6 +162,21 @@ func TestQueryClientMatchWithScroll(t *testing.T) {
 		assert.Equal(t, len(sut.Hits.Hits), 5)
 	})
 
+	t.Run("ClearsScroll", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "createQuestionSokrates", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"}, WithTransport(transport))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery(match, word)
+
+		_, err = testClient.Query().MatchWithScroll(index, body)
+		assert.Nil(t, err)
+		requests := transport.Requests()
+		assert.Equal(t, 2, len(requests))
+		assert.Equal(t, http.MethodDelete, requests[1].Method)
+		assert.True(t, strings.HasPrefix(requests[1].URL.Path, "/_search/scroll/"))
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		file := "serviceDown"
 		status := 502
@@ -247,6 +317,310 @@ func TestQueryClientMatchAggregate(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Nil(t, sut)
 	})
+
+	t.Run("NamedAggregations", func(t *testing.T) {
+		file := "aggregateMixed"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().Aggregate("writers", "author")
+		sut, err := testClient.Query().MatchAggregate(index, body)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(sut.Aggregations.AuthorAggregation.Buckets))
+
+		aggregations := sut.Aggregations.ByName
+		writers := aggregations.Buckets("writers")
+		assert.Equal(t, 2, len(writers))
+		assert.Equal(t, "herodotos", writers[0].Key)
+		assert.Equal(t, int64(4), writers[0].DocCount)
+		chapters, ok := writers[0].Aggregations.Value("chapters")
+		assert.True(t, ok)
+		assert.Equal(t, 12.5, chapters)
+
+		uniqueBooks, ok := aggregations.Value("unique_books")
+		assert.True(t, ok)
+		assert.Equal(t, float64(9), uniqueBooks)
+
+		_, ok = aggregations.Value("last_chapter")
+		assert.False(t, ok)
+
+		stats, ok := aggregations.Get("chapter_stats")
+		assert.True(t, ok)
+		assert.Equal(t, 10.5, stats.Values["avg"])
+		assert.Equal(t, float64(63), stats.Values["sum"])
+
+		perBook, _ := aggregations.Get("per_book")
+		bucket, ok := perBook.Bucket(5.0)
+		assert.True(t, ok)
+		assert.Equal(t, int64(4), bucket.DocCount)
+
+		periods := aggregations.Buckets("per_period")
+		assert.Equal(t, "archaic", periods[0].Key)
+		assert.Equal(t, -480.0, periods[1].Values["from"])
+
+		translations, _ := aggregations.Get("translations")
+		assert.Equal(t, int64(14), translations.DocCount)
+		assert.Equal(t, 2, len(translations.Aggregations.Buckets("languages")))
+	})
+
+	t.Run("BucketRoundTrip", func(t *testing.T) {
+		file := "aggregateMixed"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchAggregate(index, testClient.Builder().Aggregate("writers", "author"))
+		assert.Nil(t, err)
+
+		writer := sut.Aggregations.ByName.Buckets("writers")[0]
+		marshalled, err := json.Marshal(writer)
+		assert.Nil(t, err)
+
+		var decoded models.Bucket
+		assert.Nil(t, json.Unmarshal(marshalled, &decoded))
+		assert.Equal(t, writer.Key, decoded.Key)
+		assert.Equal(t, writer.DocCount, decoded.DocCount)
+		chapters, ok := decoded.Aggregations.Value("chapters")
+		assert.True(t, ok)
+		assert.Equal(t, 12.5, chapters)
+
+		period := sut.Aggregations.ByName.Buckets("per_period")[1]
+		marshalled, err = json.Marshal(period)
+		assert.Nil(t, err)
+		decoded = models.Bucket{}
+		assert.Nil(t, json.Unmarshal(marshalled, &decoded))
+		assert.Equal(t, period.Values, decoded.Values)
+	})
+
+	t.Run("CompositeBucket", func(t *testing.T) {
+		var composite models.AggregationResult
+		err := json.Unmarshal([]byte(`{"after_key":{"author":"plato","book":2},"buckets":[{"key":{"author":"herodotos","book":1},"doc_count":3},{"key":{"author":"plato","book":2},"doc_count":5}]}`), &composite)
+		assert.Nil(t, err)
+
+		bucket, ok := composite.Bucket(map[string]interface{}{"author": "plato", "book": float64(2)})
+		assert.True(t, ok)
+		assert.Equal(t, int64(5), bucket.DocCount)
+
+		_, ok = composite.Bucket("plato")
+		assert.False(t, ok)
+	})
+
+	t.Run("AuthorsStayDecoded", func(t *testing.T) {
+		file := "aggregate"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchAggregate(index, testClient.Builder().Aggregate(aggregate, field))
+		assert.Nil(t, err)
+		assert.Equal(t, sut.Aggregations.AuthorAggregation.Buckets, sut.Aggregations.ByName.Buckets("authors"))
+	})
+
+	t.Run("AggregationsOnMatch", func(t *testing.T) {
+		file := "aggregateMixed"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().Aggregate("writers", "author"))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(sut.Aggregations.Buckets("writers")))
+
+		marshalled, err := sut.Marshal()
+		assert.Nil(t, err)
+		assert.Contains(t, string(marshalled), `"unique_books":{`)
+	})
+}
+
+func TestQueryClientExplainProfile(t *testing.T) {
+	index := "dictionary"
+	query := NewBuilderImpl().MatchQuery("greek", "λογ")
+
+	t.Run("Explain", func(t *testing.T) {
+		file := "matchExplainProfile"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchWithOptions(index, query, WithExplain())
+		assert.Nil(t, err)
+
+		explanation := sut.Hits.Hits[0].Explanation
+		assert.NotNil(t, explanation)
+		assert.Equal(t, sut.Hits.Hits[0].Score, explanation.Value)
+		assert.Equal(t, "[dictionary][0]", sut.Hits.Hits[0].Shard)
+		assert.Equal(t, 2, len(explanation.Details[0].Details))
+		assert.Equal(t, "boost", explanation.Details[0].Details[0].Description)
+
+		lines := strings.Split(strings.TrimSpace(explanation.String()), "\n")
+		assert.Equal(t, 4, len(lines))
+		assert.True(t, strings.HasPrefix(lines[2], "    2.2 boost"))
+	})
+
+	t.Run("Profile", func(t *testing.T) {
+		file := "matchExplainProfile"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchWithOptions(index, query, WithProfile())
+		assert.Nil(t, err)
+		assert.NotNil(t, sut.Profile)
+
+		shard := sut.Profile.Shards[0]
+		termQuery := shard.Searches[0].Query[0]
+		assert.Equal(t, "TermQuery", termQuery.Type)
+		assert.Equal(t, 182734*time.Nanosecond, termQuery.Time())
+		assert.Equal(t, int64(93412), termQuery.Breakdown["create_weight"])
+		assert.Equal(t, "search_top_hits", shard.Searches[0].Collector[0].Reason)
+	})
+
+	t.Run("RequestBody", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "matchExplainProfile", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"}, WithTransport(transport))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().MatchWithOptions(index, query, WithExplain(), WithProfile())
+		assert.Nil(t, err)
+		assert.Equal(t, true, transport.Requests()[0].JSON()["explain"])
+		assert.Equal(t, true, transport.Requests()[0].JSON()["profile"])
+		assert.NotContains(t, query, "explain")
+	})
+
+	t.Run("WithoutDebugging", func(t *testing.T) {
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchWithOptions(index, query)
+		assert.Nil(t, err)
+		assert.Nil(t, sut.Profile)
+		assert.Nil(t, sut.Hits.Hits[0].Explanation)
+	})
+}
+
+func TestQueryClientCollapse(t *testing.T) {
+	index := "dictionary"
+
+	t.Run("InnerHits", func(t *testing.T) {
+		file := "matchCollapsed"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().Collapse(testClient.Builder().MatchQuery("greek", "λογ"), CollapseConfig{
+			Field:     "lemma",
+			InnerHits: []InnerHitsConfig{{Name: "entries", Size: 3}},
+		})
+		sut, err := testClient.Query().Match(index, body)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(sut.Hits.Hits))
+
+		entries := sut.Hits.Hits[0].InnerHits["entries"]
+		assert.Equal(t, int64(3), entries.Hits.Total.Value)
+		assert.Equal(t, 2, len(entries.Hits.Hits))
+		assert.Equal(t, "λόγου", entries.Hits.Hits[1].Source["greek"])
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		file := "matchCollapsed"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		type entry struct {
+			Greek string `json:"greek"`
+			Lemma string `json:"lemma"`
+		}
+
+		sut, err := Search[entry](testClient.Query(), index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "λέγω", sut.Hits.Hits[1].Source.Lemma)
+		assert.Equal(t, 1, len(sut.Hits.Hits[1].InnerHits["entries"].Hits.Hits))
+	})
+}
+
+func TestQueryClientSourceFiltering(t *testing.T) {
+	index := "herodotos"
+	config := models.Config{
+		Service: "http://localhost:9200",
+	}
+
+	t.Run("Fields", func(t *testing.T) {
+		file := "matchFields"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchWithOptions(index, testClient.Builder().MatchAll(),
+			WithSourceIncludes("author", "book"),
+			WithDocvalueFields("author", "chapter"),
+		)
+		assert.Nil(t, err)
+
+		hit := sut.Hits.Hits[0]
+		assert.Equal(t, 2, len(hit.Source))
+		assert.NotContains(t, hit.Source, "greek")
+		assert.Equal(t, []interface{}{"herodotos"}, hit.Fields["author"])
+		assert.Equal(t, []interface{}{float64(1)}, hit.Fields["chapter"])
+	})
+
+	t.Run("RequestBody", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "matchFields", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchAll()
+		body["_source"] = map[string]interface{}{"excludes": []string{"translations"}}
+
+		_, err = testClient.Query().MatchWithOptions(index, body,
+			WithSourceIncludes("author", "book.*"),
+			WithStoredFields("chapter"),
+			WithDocvalueFields("author"),
+		)
+		assert.Nil(t, err)
+
+		sent := transport.Requests()[0].JSON()
+		assert.Equal(t, map[string]interface{}{
+			"includes": []interface{}{"author", "book.*"},
+			"excludes": []interface{}{"translations"},
+		}, sent["_source"])
+		assert.Equal(t, []interface{}{"chapter"}, sent["stored_fields"])
+		assert.Equal(t, []interface{}{"author"}, sent["docvalue_fields"])
+		assert.Equal(t, map[string]interface{}{"excludes": []string{"translations"}}, body["_source"])
+	})
+
+	t.Run("WithoutSource", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "matchFields", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().MatchWithOptions(index, testClient.Builder().MatchAll(), WithoutSource(), WithSourceExcludes("greek"))
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]interface{}{"excludes": []interface{}{"greek"}}, transport.Requests()[0].JSON()["_source"])
+
+		_, err = testClient.Query().MatchWithOptions(index, testClient.Builder().MatchAll(), WithoutSource())
+		assert.Nil(t, err)
+		assert.Equal(t, false, transport.Requests()[1].JSON()["_source"])
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		file := "matchFields"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		type text struct {
+			Author string `json:"author"`
+		}
+
+		sut, err := Search[text](testClient.Query(), index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "herodotos", sut.Hits.Hits[0].Source.Author)
+		assert.Equal(t, []interface{}{float64(1)}, sut.Hits.Hits[0].Fields["chapter"])
+	})
 }
 
 func TestQueryClientSort(t *testing.T) {
@@ -278,6 +652,39 @@ func TestQueryClientSort(t *testing.T) {
 		assert.Equal(t, size, len(sut.Hits.Hits))
 	})
 
+	t.Run("SortInBody", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "sorted", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"}, WithTransport(transport))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MultipleMatch(query)
+		_, err = testClient.Query().MatchWithSort(index, mode, sort, size, body)
+		assert.Nil(t, err)
+		assert.Equal(t, float64(size), transport.Requests()[0].JSON()["size"])
+		assert.Equal(t, []interface{}{map[string]interface{}{sort: map[string]interface{}{"order": mode, "mode": "max"}}}, transport.Requests()[0].JSON()["sort"])
+		assert.NotContains(t, body, "sort")
+	})
+
+	t.Run("MultipleFields", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "sorted", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"}, WithTransport(transport))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MultipleMatch(query)
+		sut, err := testClient.Query().MatchWithOptions(index, body, WithSort(
+			SortField{Field: sort, Order: SortAscending},
+			SortField{Field: SortByScore, Order: SortDescending},
+		))
+		assert.Nil(t, err)
+		assert.Equal(t, size, len(sut.Hits.Hits))
+
+		sorted := transport.Requests()[0].JSON()["sort"].([]interface{})
+		assert.Equal(t, 2, len(sorted))
+		assert.Equal(t, map[string]interface{}{sort: map[string]interface{}{"order": "asc"}}, sorted[0])
+		assert.Equal(t, map[string]interface{}{"_score": map[string]interface{}{"order": "desc"}}, sorted[1])
+		assert.NotContains(t, body, "sort")
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		file := "serviceDown"
 		status := 502
@@ -335,3 +742,127 @@ func TestQueryClientSort(t *testing.T) {
 		assert.Nil(t, sut)
 	})
 }
+
+func TestQueryClientCount(t *testing.T) {
+	index := "herodotos,sokrates"
+	expectedMalformed := "invalid character"
+
+	t.Run("CountPass", func(t *testing.T) {
+		file := "count"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchQuery("author", "herodotos")
+		sut, err := testClient.Query().Count(index, body)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(236), sut)
+	})
+
+	t.Run("OnlyQueryIsSent", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "count", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"}, WithTransport(transport))
+		assert.Nil(t, err)
+
+		body := testClient.Builder().MatchAll()
+		body["size"] = 10
+		body["sort"] = []string{"chapter"}
+
+		_, err = testClient.Query().Count(index, body)
+		assert.Nil(t, err)
+		assert.Equal(t, "/herodotos,sokrates/_count", transport.Requests()[0].URL.Path)
+		assert.Equal(t, map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}}, transport.Requests()[0].JSON())
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		file := "deleteIndex404"
+		status := 404
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Count(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, int64(0), sut)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		file := "malformed"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Count(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), expectedMalformed)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		config := models.Config{
+			Service: "http://localhost:9200",
+		}
+		testClient, err := NewClient(config)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = testClient.Query().CountContext(ctx, index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestQueryClientSuggest(t *testing.T) {
+	index := "dictionary"
+
+	t.Run("Completion", func(t *testing.T) {
+		file := "suggestCompletion"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().CompletionSuggest("lemma", "πολε", "suggest", 5)
+		sut, err := testClient.Query().Suggest(index, body)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(sut["lemma"]))
+
+		options := sut["lemma"][0].Options
+		assert.Equal(t, 2, len(options))
+		assert.Equal(t, "πόλεμος", options[0].Text)
+		assert.Equal(t, 4.0, options[0].Score)
+		assert.Equal(t, "kql-K3wBQcJL3VaFORqk", options[0].ID)
+		assert.Equal(t, "war", options[0].Source["english"])
+	})
+
+	t.Run("TermAndPhrase", func(t *testing.T) {
+		file := "suggestTerm"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().TermSuggest("correction", "λογος", "greek")
+		sut, err := testClient.Query().Suggest(index, body)
+		assert.Nil(t, err)
+
+		term := sut["correction"][0].Options[0]
+		assert.Equal(t, "λόγος", term.Text)
+		assert.Equal(t, 0.8, term.Score)
+		assert.Equal(t, int64(42), term.Freq)
+
+		phrase := sut["phrase"][0].Options[0]
+		assert.Equal(t, "ὁ λόγος", phrase.Text)
+		assert.Equal(t, "<em>ὁ</em> <em>λόγος</em>", phrase.Highlighted)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		file := "serviceDown"
+		status := 502
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Suggest(index, testClient.Builder().TermSuggest("correction", "λογος", "greek"))
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+}
@@ -0,0 +1,217 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/odysseia-greek/aristoteles/models"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const defaultReloadInterval = 30 * time.Second
+
+// ReloadConfig lists the mounted files a client watches. Every path is optional, files that are set replace the
+// matching value from models.Config. Kubernetes updates mounted secrets by swapping a symlink so the files are polled
+// instead of relying on file system events.
+type ReloadConfig struct {
+	CertPath       string
+	ClientCertPath string
+	ClientKeyPath  string
+	UsernamePath   string
+	PasswordPath   string
+	APIKeyPath     string
+	// Interval between two checks, defaults to 30 seconds.
+	Interval time.Duration
+	// OnReload is called after every attempt to apply changed files, with the error when the new files are unusable.
+	// The client keeps using the previous transport and credentials until a reload succeeds. OnReload runs on the
+	// goroutine that polls the files and must not block, the files are not checked again until it returns.
+	OnReload func(err error)
+}
+
+// WithReload watches the files in reload until ctx is done and rebuilds the transport when they change.
+// Requests that are in flight finish on the transport they started on.
+func WithReload(ctx context.Context, reload ReloadConfig) Option {
+	return func(s *settings) {
+		s.reload = &reload
+		s.reloadCtx = ctx
+	}
+}
+
+type reloadState struct {
+	transport     *http.Transport
+	authorization string
+	checksum      [sha256.Size]byte
+}
+
+// reloadTransport sends every request through the transport that was built from the latest version of the files.
+type reloadTransport struct {
+	config   models.Config
+	settings *settings
+	reload   ReloadConfig
+	current  atomic.Pointer[reloadState]
+	// rejected is the checksum of the last files that could not be applied, so they are only reported once.
+	rejected [sha256.Size]byte
+}
+
+func createWithReload(config models.Config, settings *settings) (*elasticsearch.Client, error) {
+	settings.log().Info("creating elasticClient with reloadable credentials")
+
+	transport := &reloadTransport{
+		config:   config,
+		settings: settings,
+		reload:   *settings.reload,
+	}
+
+	if _, err := transport.load(); err != nil {
+		return nil, err
+	}
+
+	cfg := settings.elasticConfig(config)
+	cfg.Transport = settings.wrapTransport(transport)
+	es, err := elasticsearch.NewClient(cfg)
+	if err != nil {
+		settings.log().Error("error creating the client", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	ctx := settings.reloadCtx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	go transport.watch(ctx)
+
+	return es, nil
+}
+
+func (r *reloadTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	state := r.current.Load()
+	if state.authorization != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", state.authorization)
+	}
+
+	return state.transport.RoundTrip(req)
+}
+
+func (r *reloadTransport) watch(ctx context.Context) {
+	interval := r.reload.Interval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.load()
+			if !changed && err == nil {
+				continue
+			}
+
+			if err != nil {
+				r.settings.log().Error("reloading elastic credentials failed", slog.String("error", err.Error()))
+			} else {
+				r.settings.log().Info("reloaded elastic credentials")
+			}
+
+			if r.reload.OnReload != nil {
+				r.reload.OnReload(err)
+			}
+		}
+	}
+}
+
+// load reads the files and swaps in a new transport when their content changed since the last load.
+func (r *reloadTransport) load() (bool, error) {
+	config := r.config
+	files := []struct {
+		path   string
+		target *string
+	}{
+		{r.reload.CertPath, &config.ElasticCERT},
+		{r.reload.ClientCertPath, &config.ClientCERT},
+		{r.reload.ClientKeyPath, &config.ClientKey},
+		{r.reload.UsernamePath, &config.Username},
+		{r.reload.PasswordPath, &config.Password},
+		{r.reload.APIKeyPath, &config.APIKey},
+	}
+
+	var contents bytes.Buffer
+	for _, file := range files {
+		if file.path == "" {
+			contents.WriteByte(0)
+			continue
+		}
+
+		content, err := os.ReadFile(file.path)
+		if err != nil {
+			return false, fmt.Errorf("reading %s: %w", file.path, err)
+		}
+
+		*file.target = strings.TrimSpace(string(content))
+		contents.Write(content)
+		contents.WriteByte(0)
+	}
+
+	checksum := sha256.Sum256(contents.Bytes())
+	previous := r.current.Load()
+	if previous != nil && (previous.checksum == checksum || r.rejected == checksum) {
+		return false, nil
+	}
+
+	state, err := r.newState(config, checksum)
+	if err != nil {
+		r.rejected = checksum
+		return false, err
+	}
+
+	r.current.Store(state)
+
+	if previous != nil {
+		previous.transport.CloseIdleConnections()
+	}
+
+	return true, nil
+}
+
+func (r *reloadTransport) newState(config models.Config, checksum [sha256.Size]byte) (*reloadState, error) {
+	var authorization string
+
+	switch {
+	case r.reload.APIKeyPath != "" && config.APIKey != "":
+		authorization = fmt.Sprintf("APIKey %s", config.APIKey)
+	case r.reload.UsernamePath != "" || r.reload.PasswordPath != "":
+		credentials := fmt.Sprintf("%s:%s", config.Username, config.Password)
+		authorization = fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(credentials)))
+	}
+
+	transport, err := r.settings.httpTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	if usesTLS(config, r.settings) {
+		transport.TLSClientConfig, err = newTLSConfig(transport.TLSClientConfig, config, r.settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &reloadState{
+		transport:     transport,
+		authorization: authorization,
+		checksum:      checksum,
+	}, nil
+}
@@ -0,0 +1,151 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/pem"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type authRecorder struct {
+	sync.Mutex
+	passwords []string
+}
+
+func (a *authRecorder) last() string {
+	a.Lock()
+	defer a.Unlock()
+	return a.passwords[len(a.passwords)-1]
+}
+
+func recordingServer(t *testing.T, recorder *authRecorder) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, password, _ := r.BasicAuth()
+		recorder.Lock()
+		recorder.passwords = append(recorder.passwords, password)
+		recorder.Unlock()
+
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		body := fixture("match.json")
+		defer body.Close()
+		io.Copy(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// replaceFile swaps in content at once like kubernetes does for a mounted secret, so the watcher never reads a
+// truncated file.
+func replaceFile(t *testing.T, path string, content []byte) {
+	temp := path + ".tmp"
+	assert.Nil(t, os.WriteFile(temp, content, 0600))
+	assert.Nil(t, os.Rename(temp, path))
+}
+
+// notify reports reloads on reloaded without blocking the watcher when the test is not reading.
+func notify(reloaded chan error) func(err error) {
+	return func(err error) {
+		select {
+		case reloaded <- err:
+		default:
+		}
+	}
+}
+
+func TestClientReload(t *testing.T) {
+	index := "test"
+
+	t.Run("Credentials", func(t *testing.T) {
+		dir := t.TempDir()
+		usernamePath := filepath.Join(dir, "username")
+		passwordPath := filepath.Join(dir, "password")
+		assert.Nil(t, os.WriteFile(usernamePath, []byte("aristoteles"), 0600))
+		assert.Nil(t, os.WriteFile(passwordPath, []byte("first\n"), 0600))
+
+		recorder := &authRecorder{}
+		server := recordingServer(t, recorder)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		reloaded := make(chan error, 1)
+		testClient, err := NewClient(models.Config{Service: server.URL, Password: "fromConfig"}, WithReload(ctx, ReloadConfig{
+			UsernamePath: usernamePath,
+			PasswordPath: passwordPath,
+			Interval:     5 * time.Millisecond,
+			OnReload:     notify(reloaded),
+		}))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "first", recorder.last())
+
+		replaceFile(t, passwordPath, []byte("second\n"))
+		select {
+		case err := <-reloaded:
+			assert.Nil(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("credentials were not reloaded")
+		}
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "second", recorder.last())
+	})
+
+	t.Run("InvalidCertificateKeepsTransport", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			body := fixture("match.json")
+			defer body.Close()
+			io.Copy(w, body)
+		}))
+		defer server.Close()
+
+		dir := t.TempDir()
+		certPath := filepath.Join(dir, "tls.crt")
+		serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		assert.Nil(t, os.WriteFile(certPath, serverCA, 0600))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		reloaded := make(chan error, 1)
+		testClient, err := NewClient(models.Config{Service: server.URL}, WithReload(ctx, ReloadConfig{
+			CertPath: certPath,
+			Interval: 5 * time.Millisecond,
+			OnReload: notify(reloaded),
+		}))
+		assert.Nil(t, err)
+
+		replaceFile(t, certPath, []byte("rotated but broken"))
+		select {
+		case err := <-reloaded:
+			assert.NotNil(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("certificate was not reloaded")
+		}
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), sut.Hits.Total.Value)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"}, WithReload(context.Background(), ReloadConfig{
+			PasswordPath: filepath.Join(t.TempDir(), "password"),
+		}))
+		assert.NotNil(t, err)
+		assert.Nil(t, testClient)
+	})
+}
@@ -0,0 +1,168 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetries        = 3
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+// idempotentEndpoints are the POST endpoints that only read, searches send their query as a POST body.
+var idempotentEndpoints = []string{"/_search", "/_msearch", "/_count", "/_mget", "/_search/template", "/_msearch/template"}
+
+// RetryConfig configures the retries of idempotent requests: reads such as search, get and the health check.
+// Requests that write are never retried by the client since elasticsearch may have applied them already.
+type RetryConfig struct {
+	// Retries is the number of retries after the first attempt, defaults to 3.
+	Retries int
+	// InitialBackoff is the upper bound of the wait before the first retry, it doubles with every retry up to
+	// MaxBackoff. The actual wait is picked at random below the bound so clients do not retry in lockstep.
+	// Defaults to 100ms and 5s.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryOnStatus are the status codes that are retried, defaults to 429, 502, 503 and 504.
+	// Connection errors are always retried.
+	RetryOnStatus []int
+}
+
+// WithRetry retries idempotent requests with jittered exponential backoff. It replaces the retries of the
+// elasticsearch transport, which retries every request without waiting, so WithMaxRetries has no effect.
+// WithRequestTimeout bounds every attempt on its own, bound the whole call including the backoff with the context.
+func WithRetry(retry RetryConfig) Option {
+	return func(s *settings) {
+		if retry.Retries <= 0 {
+			retry.Retries = defaultRetries
+		}
+		if retry.InitialBackoff <= 0 {
+			retry.InitialBackoff = defaultInitialBackoff
+		}
+		if retry.MaxBackoff <= 0 {
+			retry.MaxBackoff = defaultMaxBackoff
+		}
+		if len(retry.RetryOnStatus) == 0 {
+			retry.RetryOnStatus = []int{
+				http.StatusTooManyRequests,
+				http.StatusBadGateway,
+				http.StatusServiceUnavailable,
+				http.StatusGatewayTimeout,
+			}
+		}
+
+		s.retry = &retry
+	}
+}
+
+type retryTransport struct {
+	next   http.RoundTripper
+	config RetryConfig
+}
+
+// RoundTrip sends a clone of req on every attempt so the request of the caller is never changed.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !idempotent(req) {
+		return t.next.RoundTrip(req)
+	}
+
+	base := req.Clone(req.Context())
+	if base.Body != nil && base.Body != http.NoBody && base.GetBody == nil {
+		body, err := io.ReadAll(base.Body)
+		base.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		base.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		try := base.Clone(base.Context())
+		if base.GetBody != nil {
+			body, err := base.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			try.Body = body
+		}
+
+		res, err := t.next.RoundTrip(try)
+		if attempt >= t.config.Retries || !t.shouldRetry(base, res, err) {
+			return res, err
+		}
+
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if err := sleep(base.Context(), t.backoff(attempt)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil && !errors.Is(err, ErrCircuitOpen)
+	}
+
+	for _, status := range t.config.RetryOnStatus {
+		if res.StatusCode == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff picks a random wait below InitialBackoff * 2^attempt, capped at MaxBackoff.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	bound := t.config.InitialBackoff << attempt
+	if bound <= 0 || bound > t.config.MaxBackoff {
+		bound = t.config.MaxBackoff
+	}
+
+	return time.Duration(rand.Int63n(int64(bound)) + 1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		if req.URL.Query().Has("scroll") {
+			return false
+		}
+
+		for _, endpoint := range idempotentEndpoints {
+			if strings.HasSuffix(req.URL.Path, endpoint) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+package aristoteles
+
+import (
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClientRetry(t *testing.T) {
+	index := "test"
+	config := models.Config{
+		Service: "http://localhost:9200",
+	}
+	retry := RetryConfig{
+		Retries:        2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}
+
+	t.Run("SearchRecovers", func(t *testing.T) {
+		transport := NewSequentialTransport(
+			MockResponse{Fixture: "serviceDown", StatusCode: 502},
+			MockResponse{Fixture: "serviceDown", StatusCode: 503},
+			MockResponse{Fixture: "match", StatusCode: 200},
+		)
+		testClient, err := NewClient(config,
+			WithRetry(retry),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), sut.Hits.Total.Value)
+		requests := transport.Requests()
+		assert.Equal(t, 3, len(requests))
+
+		first, err := io.ReadAll(requests[0].Body)
+		assert.Nil(t, err)
+		last, err := io.ReadAll(requests[2].Body)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, last)
+		assert.Equal(t, first, last)
+	})
+
+	t.Run("GivesUp", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "serviceDown", StatusCode: 502})
+		testClient, err := NewClient(config,
+			WithRetry(retry),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+		assert.True(t, IsRetryable(err))
+		assert.Equal(t, 3, len(transport.Requests()))
+	})
+
+	t.Run("WritesAreNotRetried", func(t *testing.T) {
+		transport := NewSequentialTransport(
+			MockResponse{Fixture: "serviceDown", StatusCode: 502},
+			MockResponse{Fixture: "match", StatusCode: 200},
+		)
+		testClient, err := NewClient(config,
+			WithRetry(retry),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		_, err = testClient.Document().Create(index, []byte(`{"greek":"λόγος"}`))
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, len(transport.Requests()))
+	})
+
+	t.Run("ClientErrorsAreNotRetried", func(t *testing.T) {
+		transport := NewSequentialTransport(
+			MockResponse{Fixture: "serviceDown", StatusCode: 404},
+			MockResponse{Fixture: "match", StatusCode: 200},
+		)
+		testClient, err := NewClient(config,
+			WithRetry(retry),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Equal(t, 1, len(transport.Requests()))
+	})
+
+	t.Run("Backoff", func(t *testing.T) {
+		transport := &retryTransport{config: RetryConfig{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}}
+		for attempt := 0; attempt < 10; attempt++ {
+			sut := transport.backoff(attempt)
+			assert.True(t, sut > 0)
+			assert.True(t, sut <= 50*time.Millisecond)
+			if attempt == 0 {
+				assert.True(t, sut <= 10*time.Millisecond)
+			}
+		}
+	})
+
+	t.Run("CallerRequestUnchanged", func(t *testing.T) {
+		transport := NewSequentialTransport(
+			MockResponse{Fixture: "serviceDown", StatusCode: 503},
+			MockResponse{Fixture: "match", StatusCode: 200},
+		)
+		retrying := &retryTransport{
+			next:   transport,
+			config: RetryConfig{Retries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryOnStatus: []int{503}},
+		}
+
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:9200/test/_search", io.NopCloser(strings.NewReader(`{"size":1}`)))
+		assert.Nil(t, err)
+
+		res, err := retrying.RoundTrip(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Nil(t, req.GetBody)
+		assert.Equal(t, 2, len(transport.Requests()))
+
+		for _, sent := range transport.Requests() {
+			assert.NotSame(t, req, sent.Request)
+			body, err := io.ReadAll(sent.Body)
+			assert.Nil(t, err)
+			assert.Equal(t, `{"size":1}`, string(body))
+		}
+	})
+
+	t.Run("Idempotent", func(t *testing.T) {
+		for path, expected := range map[string]bool{
+			"http://localhost:9200/test/_search":             true,
+			"http://localhost:9200/test/_search?scroll=5s":   false,
+			"http://localhost:9200/test/_count":              true,
+			"http://localhost:9200/test/_doc":                false,
+			"http://localhost:9200/_security/user/aristotle": false,
+		} {
+			req, err := http.NewRequest(http.MethodPost, path, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, idempotent(req), path)
+		}
+
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:9200/", nil)
+		assert.Nil(t, err)
+		assert.True(t, idempotent(req))
+	})
+}
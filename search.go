@@ -0,0 +1,83 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/odysseia-greek/aristoteles/models"
+	"io"
+)
+
+// Search runs request against index with opts applied as in MatchWithOptions and decodes the _source of every hit
+// into T.
+func Search[T any](q Query, index string, request map[string]interface{}, opts ...SearchOption) (*models.SearchResponse[T], error) {
+	return SearchContext[T](context.Background(), q, index, request, opts...)
+}
+
+// SearchContext is Search with a context. Queries that are not created by this package are searched with
+// MatchWithOptionsContext and their hits converted, which costs the extra JSON round trip Search avoids.
+func SearchContext[T any](ctx context.Context, q Query, index string, request map[string]interface{}, opts ...SearchOption) (*models.SearchResponse[T], error) {
+	impl, ok := q.(*QueryImpl)
+	if !ok {
+		return convertResponse[T](q.MatchWithOptionsContext(ctx, index, request, opts...))
+	}
+
+	return searchTyped[T](ctx, impl, index, applySearchOptions(request, opts))
+}
+
+func searchTyped[T any](ctx context.Context, q *QueryImpl, index string, request map[string]interface{}) (result *models.SearchResponse[T], err error) {
+	ctx, op := q.instrument.begin(ctx, "search", index)
+	defer func() { op.end(err) }()
+
+	query, err := toBuffer(request)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := q.es.Search(
+		q.es.Search.WithContext(ctx),
+		q.es.Search.WithIndex(index),
+		q.es.Search.WithBody(&query),
+		q.es.Search.WithTrackTotalHits(true),
+	)
+	op.response(res)
+
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newElasticError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := models.UnmarshalSearchResponse[T](body)
+	if err != nil {
+		return nil, err
+	}
+	op.result(response.Hits.Total.Value, response.Took, len(response.Hits.Hits))
+
+	return &response, nil
+}
+
+func convertResponse[T any](response *models.Response, err error) (*models.SearchResponse[T], error) {
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := models.UnmarshalSearchResponse[T](body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &converted, nil
+}
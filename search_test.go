@@ -0,0 +1,92 @@
+package aristoteles
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type meros struct {
+	Greek    string `json:"greek"`
+	English  string `json:"english"`
+	Original string `json:"original"`
+}
+
+type nomen struct {
+	Greek       string `json:"greek"`
+	Translation string `json:"translation"`
+	Chapter     int    `json:"chapter"`
+}
+
+// externalQuery hides the implementation so Search has to fall back to MatchContext.
+type externalQuery struct {
+	Query
+}
+
+func TestSearch(t *testing.T) {
+	index := "test"
+
+	t.Run("DecodesSource", func(t *testing.T) {
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := Search[meros](testClient.Query(), index, testClient.Builder().MatchQuery("greek", "πολεμος"))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), sut.Hits.Total.Value)
+		assert.Equal(t, 1, len(sut.Hits.Hits))
+
+		hit := sut.Hits.Hits[0]
+		assert.Equal(t, "kql-K3wBQcJL3VaFORqk", hit.ID)
+		assert.Equal(t, index, hit.Index)
+		assert.Equal(t, 5.93497, hit.Score)
+		assert.Equal(t, "war", hit.Source.English)
+		assert.Equal(t, []meros{hit.Source}, sut.Hits.Sources())
+	})
+
+	t.Run("SortValues", func(t *testing.T) {
+		file := "sorted"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := Search[nomen](testClient.Query(), index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, 15, sut.Hits.Hits[0].Source.Chapter)
+		assert.Equal(t, []interface{}{float64(15)}, sut.Hits.Hits[0].Sort)
+	})
+
+	t.Run("ExternalQuery", func(t *testing.T) {
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := Search[meros](externalQuery{testClient.Query()}, index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "kql-K3wBQcJL3VaFORqk", sut.Hits.Hits[0].ID)
+		assert.Equal(t, "πόλεμος –ου, ὁ", sut.Hits.Hits[0].Source.Original)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		file := "serviceDown"
+		status := 502
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := Search[meros](testClient.Query(), index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("SourceDoesNotFit", func(t *testing.T) {
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := Search[[]string](testClient.Query(), index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+}
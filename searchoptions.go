@@ -0,0 +1,136 @@
+package aristoteles
+
+import (
+	"context"
+	"github.com/odysseia-greek/aristoteles/models"
+)
+
+// SearchOption changes the body of a search sent with MatchWithOptions, the request of the caller is left as is.
+type SearchOption func(body map[string]interface{})
+
+// WithSort sorts the hits on fields in priority order. Hits are not scored when sorting on fields only, add
+// SortByScore to break ties on relevance.
+func WithSort(fields ...SortField) SearchOption {
+	return func(body map[string]interface{}) {
+		body["sort"] = sortBody(fields)
+	}
+}
+
+// WithExplain adds to every hit how its score was computed, see models.Hit.Explanation. Explaining is expensive and
+// meant for debugging relevance.
+func WithExplain() SearchOption {
+	return func(body map[string]interface{}) {
+		body["explain"] = true
+	}
+}
+
+// WithProfile adds the timings of the query and aggregations per shard to models.Response.Profile. The timings are
+// slower than an unprofiled search and meant for debugging.
+func WithProfile() SearchOption {
+	return func(body map[string]interface{}) {
+		body["profile"] = true
+	}
+}
+
+// WithSourceIncludes only returns fields of the _source of every hit, fields may hold wildcards such as "book.*".
+func WithSourceIncludes(fields ...string) SearchOption {
+	return func(body map[string]interface{}) {
+		sourceFilter(body)["includes"] = fields
+	}
+}
+
+// WithSourceExcludes leaves fields out of the _source of every hit, it is applied after WithSourceIncludes.
+func WithSourceExcludes(fields ...string) SearchOption {
+	return func(body map[string]interface{}) {
+		sourceFilter(body)["excludes"] = fields
+	}
+}
+
+// WithoutSource returns hits without their _source, for searches that only need ids, fields or aggregations.
+func WithoutSource() SearchOption {
+	return func(body map[string]interface{}) {
+		body["_source"] = false
+	}
+}
+
+// WithStoredFields returns fields that are stored in the mapping in the Fields of every hit. The _source is no longer
+// returned unless it is asked for with WithSourceIncludes.
+func WithStoredFields(fields ...string) SearchOption {
+	return func(body map[string]interface{}) {
+		body["stored_fields"] = fields
+	}
+}
+
+// WithDocvalueFields returns fields from their doc values in the Fields of every hit, which is cheaper than loading
+// the _source for keyword, numeric and date fields.
+func WithDocvalueFields(fields ...string) SearchOption {
+	return func(body map[string]interface{}) {
+		body["docvalue_fields"] = fields
+	}
+}
+
+func (q *QueryImpl) MatchWithOptions(index string, request map[string]interface{}, opts ...SearchOption) (*models.Response, error) {
+	return q.MatchWithOptionsContext(context.Background(), index, request, opts...)
+}
+
+func (q *QueryImpl) MatchWithOptionsContext(ctx context.Context, index string, request map[string]interface{}, opts ...SearchOption) (result *models.Response, err error) {
+	ctx, op := q.instrument.begin(ctx, "search", index)
+	defer func() { op.end(err) }()
+
+	query, err := toBuffer(applySearchOptions(request, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := q.es.Search(
+		q.es.Search.WithContext(ctx),
+		q.es.Search.WithIndex(index),
+		q.es.Search.WithBody(&query),
+		q.es.Search.WithTrackTotalHits(true),
+		q.es.Search.WithPretty(),
+	)
+	op.response(res)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err = q.parseResponse(res)
+	if err != nil {
+		return nil, err
+	}
+	op.result(result.Hits.Total.Value, result.Took, len(result.Hits.Hits))
+
+	return result, nil
+}
+
+// sourceFilter returns the _source filter of body, replacing a filter set by the request so it is not changed.
+func sourceFilter(body map[string]interface{}) map[string]interface{} {
+	filter := map[string]interface{}{}
+	if existing, ok := body["_source"].(map[string]interface{}); ok {
+		for key, value := range existing {
+			filter[key] = value
+		}
+	}
+	body["_source"] = filter
+
+	return filter
+}
+
+// applySearchOptions returns a copy of request with opts applied, request itself is returned without options.
+func applySearchOptions(request map[string]interface{}, opts []SearchOption) map[string]interface{} {
+	if len(opts) == 0 {
+		return request
+	}
+
+	body := make(map[string]interface{}, len(request)+len(opts))
+	for key, value := range request {
+		body[key] = value
+	}
+
+	for _, opt := range opts {
+		opt(body)
+	}
+
+	return body
+}
@@ -0,0 +1,48 @@
+package aristoteles
+
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+	// SortByScore is the field to sort on relevance, add it after the other fields to break ties by score.
+	SortByScore = "_score"
+)
+
+// SortField is one field of a sort, a search sorts on the fields in the order they are given.
+type SortField struct {
+	Field string
+	// Order is SortAscending or SortDescending, empty keeps the default of elasticsearch: descending for _score and
+	// ascending for everything else.
+	Order string
+	// Mode picks the value of a field with several values: min, max, sum, avg or median.
+	Mode string
+	// Missing places documents without the field, either "_first", "_last" or a value to use instead.
+	Missing interface{}
+}
+
+func (s SortField) body() interface{} {
+	options := map[string]interface{}{}
+	if s.Order != "" {
+		options["order"] = s.Order
+	}
+	if s.Mode != "" {
+		options["mode"] = s.Mode
+	}
+	if s.Missing != nil {
+		options["missing"] = s.Missing
+	}
+
+	if len(options) == 0 {
+		return s.Field
+	}
+
+	return map[string]interface{}{s.Field: options}
+}
+
+func sortBody(fields []SortField) []interface{} {
+	sort := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		sort = append(sort, field.body())
+	}
+
+	return sort
+}
@@ -0,0 +1,246 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/odysseia-greek/aristoteles/models"
+	"io"
+	"log/slog"
+	"sort"
+)
+
+const templateLang = "mustache"
+
+// TemplateImpl manages stored search templates. Params of a template are a map or a struct with json tags, they are
+// sent as json and filled into the mustache placeholders of the template.
+type TemplateImpl struct {
+	es         *elasticsearch.Client
+	instrument *instrument
+}
+
+func NewTemplateImpl(suppliedClient *elasticsearch.Client) (*TemplateImpl, error) {
+	if suppliedClient == nil {
+		return nil, fmt.Errorf("cannot create interface with empty client")
+	}
+	return &TemplateImpl{es: suppliedClient}, nil
+}
+
+func (t *TemplateImpl) Put(id, source string) (bool, error) {
+	return t.PutContext(context.Background(), id, source)
+}
+
+// PutContext stores source as the template id, an existing template with the same id is replaced. Source is a string
+// so it can hold mustache sections such as {{#size}}, which are not valid json.
+func (t *TemplateImpl) PutContext(ctx context.Context, id, source string) (created bool, err error) {
+	ctx, op := t.instrument.begin(ctx, "put_template", "")
+	defer func() { op.end(err) }()
+
+	t.instrument.log().Info("storing search template", slog.String("template", id))
+
+	body, err := toBuffer(map[string]interface{}{
+		"script": map[string]interface{}{
+			"lang":   templateLang,
+			"source": source,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	res, err := t.es.PutScript(id, &body, t.es.PutScript.WithContext(ctx))
+	op.response(res)
+	if err != nil {
+		return false, err
+	}
+
+	return acknowledged(res)
+}
+
+func (t *TemplateImpl) List() ([]models.StoredTemplate, error) {
+	return t.ListContext(context.Background())
+}
+
+// ListContext returns the stored search templates ordered by id, stored scripts in other languages are left out.
+func (t *TemplateImpl) ListContext(ctx context.Context) (templates []models.StoredTemplate, err error) {
+	ctx, op := t.instrument.begin(ctx, "list_templates", "")
+	defer func() { op.end(err) }()
+
+	res, err := t.es.Cluster.State(
+		t.es.Cluster.State.WithContext(ctx),
+		t.es.Cluster.State.WithMetric("metadata"),
+		t.es.Cluster.State.WithFilterPath("metadata.stored_scripts"),
+	)
+	op.response(res)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newElasticError(res)
+	}
+
+	var state struct {
+		Metadata struct {
+			StoredScripts map[string]models.StoredTemplate `json:"stored_scripts"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&state); err != nil {
+		return nil, err
+	}
+
+	templates = []models.StoredTemplate{}
+	for id, template := range state.Metadata.StoredScripts {
+		if template.Lang != templateLang {
+			continue
+		}
+		template.ID = id
+		templates = append(templates, template)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].ID < templates[j].ID
+	})
+
+	return templates, nil
+}
+
+func (t *TemplateImpl) Delete(id string) (bool, error) {
+	return t.DeleteContext(context.Background(), id)
+}
+
+func (t *TemplateImpl) DeleteContext(ctx context.Context, id string) (deleted bool, err error) {
+	ctx, op := t.instrument.begin(ctx, "delete_template", "")
+	defer func() { op.end(err) }()
+
+	t.instrument.log().Info("deleting search template", slog.String("template", id))
+
+	res, err := t.es.DeleteScript(id, t.es.DeleteScript.WithContext(ctx))
+	op.response(res)
+	if err != nil {
+		return false, err
+	}
+
+	return acknowledged(res)
+}
+
+func (t *TemplateImpl) Search(index, id string, params interface{}) (*models.Response, error) {
+	return t.SearchContext(context.Background(), index, id, params)
+}
+
+// SearchContext runs the stored template id against index with params filled in.
+func (t *TemplateImpl) SearchContext(ctx context.Context, index, id string, params interface{}) (result *models.Response, err error) {
+	ctx, op := t.instrument.begin(ctx, "search_template", index)
+	defer func() { op.end(err) }()
+
+	body, err := templateBody(id, params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.es.SearchTemplate(
+		body,
+		t.es.SearchTemplate.WithContext(ctx),
+		t.es.SearchTemplate.WithIndex(index),
+	)
+	op.response(res)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newElasticError(res)
+	}
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := models.UnmarshalResponse(content)
+	if err != nil {
+		return nil, err
+	}
+	op.result(response.Hits.Total.Value, response.Took, len(response.Hits.Hits))
+
+	return &response, nil
+}
+
+func (t *TemplateImpl) Render(id string, params interface{}) (map[string]interface{}, error) {
+	return t.RenderContext(context.Background(), id, params)
+}
+
+// RenderContext returns the query the template id expands to with params without searching, it is the same kind of
+// map the Builder creates so tests can compare the two.
+func (t *TemplateImpl) RenderContext(ctx context.Context, id string, params interface{}) (rendered map[string]interface{}, err error) {
+	ctx, op := t.instrument.begin(ctx, "render_template", "")
+	defer func() { op.end(err) }()
+
+	body, err := templateBody("", params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.es.RenderSearchTemplate(
+		t.es.RenderSearchTemplate.WithContext(ctx),
+		t.es.RenderSearchTemplate.WithTemplateID(id),
+		t.es.RenderSearchTemplate.WithBody(body),
+	)
+	op.response(res)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, newElasticError(res)
+	}
+
+	var output struct {
+		TemplateOutput map[string]interface{} `json:"template_output"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&output); err != nil {
+		return nil, err
+	}
+
+	return output.TemplateOutput, nil
+}
+
+func templateBody(id string, params interface{}) (*bytes.Buffer, error) {
+	body := map[string]interface{}{}
+	if id != "" {
+		body["id"] = id
+	}
+	if params != nil {
+		body["params"] = params
+	}
+
+	buffer, err := toBuffer(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &buffer, nil
+}
+
+func acknowledged(res *esapi.Response) (bool, error) {
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return false, newElasticError(res)
+	}
+
+	var r struct {
+		Acknowledged bool `json:"acknowledged"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+		return false, err
+	}
+
+	return r.Acknowledged, nil
+}
@@ -0,0 +1,139 @@
+package aristoteles
+
+import (
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestTemplateClient(t *testing.T) {
+	id := "dictionary-search"
+	index := "dictionary"
+	source := `{"query":{"match_phrase":{"{{field}}":"{{word}}"}},"size":{{size}}}`
+	config := models.Config{
+		Service: "http://localhost:9200",
+	}
+	type dictionaryParams struct {
+		Field string `json:"field"`
+		Word  string `json:"word"`
+		Size  int    `json:"size"`
+	}
+	params := dictionaryParams{Field: "greek", Word: "λόγος", Size: 5}
+
+	t.Run("Put", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "deleteIndex", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Put(id, source)
+		assert.Nil(t, err)
+		assert.True(t, sut)
+		requests := transport.Requests()
+		assert.Equal(t, http.MethodPut, requests[0].Method)
+		assert.Equal(t, "/_scripts/"+id, requests[0].URL.Path)
+
+		script := requests[0].JSON()["script"].(map[string]interface{})
+		assert.Equal(t, "mustache", script["lang"])
+		assert.Equal(t, source, script["source"])
+	})
+
+	t.Run("PutFailed", func(t *testing.T) {
+		file := "serviceDown"
+		status := 502
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Put(id, source)
+		assert.NotNil(t, err)
+		assert.False(t, sut)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		file := "storedTemplates"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().List()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(sut))
+		assert.Equal(t, "authors-by-name", sut[0].ID)
+		assert.Equal(t, id, sut[1].ID)
+		assert.Equal(t, source, sut[1].Source)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "deleteIndex", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Delete(id)
+		assert.Nil(t, err)
+		assert.True(t, sut)
+		assert.Equal(t, http.MethodDelete, transport.Requests()[0].Method)
+		assert.Equal(t, "/_scripts/"+id, transport.Requests()[0].URL.Path)
+	})
+
+	t.Run("DeleteNotFound", func(t *testing.T) {
+		file := "templateNotFound"
+		status := 404
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Delete(id)
+		assert.False(t, sut)
+		assert.True(t, IsNotFound(err))
+		assert.Contains(t, err.Error(), "resource_not_found_exception")
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Search(index, id, params)
+		assert.Nil(t, err)
+		assert.True(t, len(sut.Hits.Hits) > 0)
+		requests := transport.Requests()
+		assert.Equal(t, "/"+index+"/_search/template", requests[0].URL.Path)
+		assert.Equal(t, id, requests[0].JSON()["id"])
+		assert.Equal(t, map[string]interface{}{"field": "greek", "word": "λόγος", "size": float64(5)}, requests[0].JSON()["params"])
+	})
+
+	t.Run("SearchNotFound", func(t *testing.T) {
+		file := "templateNotFound"
+		status := 404
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Search(index, id, map[string]interface{}{"word": "λόγος"})
+		assert.Nil(t, sut)
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("Render", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "renderTemplate", StatusCode: 200})
+		testClient, err := NewClient(config, WithTransport(transport))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Render(id, params)
+		assert.Nil(t, err)
+		assert.Equal(t, "/_render/template/"+id, transport.Requests()[0].URL.Path)
+		assert.NotContains(t, transport.Requests()[0].JSON(), "id")
+
+		assert.Equal(t, map[string]interface{}{"match_phrase": map[string]interface{}{"greek": "λόγος"}}, sut["query"])
+		assert.Equal(t, float64(5), sut["size"])
+	})
+
+	t.Run("Unparseable", func(t *testing.T) {
+		file := "renderTemplate"
+		status := 200
+		testClient, err := NewMockClient(file, status)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Template().Render(id, map[string]interface{}{"key": make(chan int)})
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+}
@@ -0,0 +1,58 @@
+package aristoteles
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/odysseia-greek/aristoteles/models"
+)
+
+// usesTLS reports whether the client needs a transport with its own tls configuration.
+func usesTLS(config models.Config, settings *settings) bool {
+	return config.ElasticCERT != "" ||
+		config.ClientCERT != "" ||
+		config.ClientKey != "" ||
+		settings.serverName != "" ||
+		settings.minTLSVersion != 0
+}
+
+// newTLSConfig builds on base, which may be nil, adding the CA from ElasticCERT to the system pool and loading the
+// client certificate for mutual tls.
+func newTLSConfig(base *tls.Config, config models.Config, settings *settings) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if base != nil {
+		tlsConfig = base.Clone()
+	}
+
+	if settings.serverName != "" {
+		tlsConfig.ServerName = settings.serverName
+	}
+
+	if settings.minTLSVersion != 0 {
+		tlsConfig.MinVersion = settings.minTLSVersion
+	}
+
+	if config.ElasticCERT != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("problem adding system CA: %w", err)
+		}
+
+		if ok := rootCAs.AppendCertsFromPEM([]byte(config.ElasticCERT)); !ok {
+			return nil, fmt.Errorf("problem adding CA: no valid PEM encoded certificate found")
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if config.ClientCERT != "" || config.ClientKey != "" {
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCERT), []byte(config.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("problem loading client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
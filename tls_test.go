@@ -0,0 +1,159 @@
+package aristoteles
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func clientCertificate(t *testing.T) (certPEM, keyPEM []byte, cert *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "aristoteles"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	cert, err = x509.ParseCertificate(der)
+	assert.Nil(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	return certPEM, keyPEM, cert
+}
+
+func mutualTLSServer(t *testing.T, clientCert *x509.Certificate) *httptest.Server {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		body := fixture("match.json")
+		defer body.Close()
+		io.Copy(w, body)
+	}))
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClientTLS(t *testing.T) {
+	index := "test"
+
+	t.Run("InvalidCA", func(t *testing.T) {
+		config := models.Config{
+			Service:     "https://localhost:9200",
+			ElasticCERT: "not a certificate",
+		}
+
+		testClient, err := NewClient(config)
+		assert.NotNil(t, err)
+		assert.Nil(t, testClient)
+		assert.Contains(t, err.Error(), "problem adding CA")
+	})
+
+	t.Run("InvalidClientKey", func(t *testing.T) {
+		certPEM, _, _ := clientCertificate(t)
+		config := models.Config{
+			Service:    "https://localhost:9200",
+			ClientCERT: string(certPEM),
+			ClientKey:  "not a key",
+		}
+
+		testClient, err := NewClient(config)
+		assert.NotNil(t, err)
+		assert.Nil(t, testClient)
+		assert.Contains(t, err.Error(), "problem loading client certificate")
+	})
+
+	t.Run("ClientKeyWithoutCertificate", func(t *testing.T) {
+		_, keyPEM, _ := clientCertificate(t)
+		config := models.Config{
+			Service:   "https://localhost:9200",
+			ClientKey: string(keyPEM),
+		}
+
+		testClient, err := NewClient(config)
+		assert.NotNil(t, err)
+		assert.Nil(t, testClient)
+		assert.Contains(t, err.Error(), "problem loading client certificate")
+	})
+
+	t.Run("MutualTLS", func(t *testing.T) {
+		certPEM, keyPEM, cert := clientCertificate(t)
+		server := mutualTLSServer(t, cert)
+		serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		config := models.Config{
+			Service:     server.URL,
+			ElasticCERT: string(serverCA),
+			ClientCERT:  string(certPEM),
+			ClientKey:   string(keyPEM),
+		}
+
+		testClient, err := NewClient(config, WithMinTLSVersion(tls.VersionTLS12))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), sut.Hits.Total.Value)
+	})
+
+	t.Run("MissingClientCertificate", func(t *testing.T) {
+		_, _, cert := clientCertificate(t)
+		server := mutualTLSServer(t, cert)
+		serverCA := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		config := models.Config{
+			Service:     server.URL,
+			ElasticCERT: string(serverCA),
+		}
+
+		testClient, err := NewClient(config, WithMaxRetries(0))
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("ServerNameOverride", func(t *testing.T) {
+		config := models.Config{
+			Service: "https://10.0.0.1:9200",
+		}
+		settings := newSettings(WithTLSServerName("elastic.odysseia"), WithMinTLSVersion(tls.VersionTLS13))
+		assert.True(t, usesTLS(config, settings))
+
+		sut, err := newTLSConfig(nil, config, settings)
+		assert.Nil(t, err)
+		assert.Equal(t, "elastic.odysseia", sut.ServerName)
+		assert.Equal(t, uint16(tls.VersionTLS13), sut.MinVersion)
+	})
+}
@@ -0,0 +1,122 @@
+package aristoteles
+
+import (
+	"context"
+	"github.com/odysseia-greek/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"testing"
+)
+
+func spanRecorder() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	return provider, recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	index := "test"
+
+	t.Run("SearchSpan", func(t *testing.T) {
+		provider, recorder := spanRecorder()
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status, WithTracerProvider(provider))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+
+		spans := recorder.Ended()
+		assert.Equal(t, 1, len(spans))
+		assert.Equal(t, "elasticsearch search", spans[0].Name())
+		assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+		attrs := spanAttributes(spans[0])
+		assert.Equal(t, "elasticsearch", attrs["db.system"].AsString())
+		assert.Equal(t, "search", attrs["db.operation"].AsString())
+		assert.Equal(t, index, attrs["db.elasticsearch.path_parts.index"].AsString())
+		assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+		assert.Equal(t, int64(1), attrs["db.elasticsearch.hits"].AsInt64())
+	})
+
+	t.Run("ParentSpan", func(t *testing.T) {
+		provider, recorder := spanRecorder()
+		file := "match"
+		status := 200
+		testClient, err := NewMockClient(file, status, WithTracerProvider(provider))
+		assert.Nil(t, err)
+
+		ctx, parent := provider.Tracer("test").Start(context.Background(), "handler")
+		_, err = testClient.Query().MatchContext(ctx, index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		parent.End()
+
+		spans := recorder.Ended()
+		assert.Equal(t, 2, len(spans))
+		assert.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	})
+
+	t.Run("ErrorStatus", func(t *testing.T) {
+		provider, recorder := spanRecorder()
+		file := "deleteIndex404"
+		status := 404
+		testClient, err := NewMockClient(file, status, WithTracerProvider(provider))
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.NotNil(t, err)
+
+		spans := recorder.Ended()
+		assert.Equal(t, 1, len(spans))
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, int64(404), spanAttributes(spans[0])["http.response.status_code"].AsInt64())
+		assert.Equal(t, 1, len(spans[0].Events()))
+	})
+
+	t.Run("PropagatesTraceContext", func(t *testing.T) {
+		provider, recorder := spanRecorder()
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"},
+			WithTracerProvider(provider),
+			WithPropagator(propagation.TraceContext{}),
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+
+		spans := recorder.Ended()
+		assert.Equal(t, 1, len(transport.Requests()))
+		assert.Equal(t, 1, len(spans))
+		assert.Contains(t, transport.Requests()[0].Header.Get("traceparent"), spans[0].SpanContext().TraceID().String())
+	})
+
+	t.Run("WithoutTracerProvider", func(t *testing.T) {
+		transport := NewSequentialTransport(MockResponse{Fixture: "match", StatusCode: 200})
+		testClient, err := NewClient(models.Config{Service: "http://localhost:9200"},
+			WithTransport(transport),
+		)
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, testClient.Builder().MatchAll())
+		assert.Nil(t, err)
+		assert.Equal(t, "", transport.Requests()[0].Header.Get("traceparent"))
+	})
+}
@@ -0,0 +1,58 @@
+package aristoteles
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/propagation"
+	"io"
+	"net/http"
+	"time"
+)
+
+// timeoutTransport cancels a request once the timeout passes. The deadline stays active until the body is closed
+// so reading a slow response is bounded as well.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	if res.Body == nil {
+		cancel()
+		return res, nil
+	}
+
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// propagationTransport adds the trace context of the request to its headers so elasticsearch can join the trace.
+type propagationTransport struct {
+	next       http.RoundTripper
+	propagator propagation.TextMapPropagator
+}
+
+func (t *propagationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	t.propagator.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+
+	return t.next.RoundTrip(req)
+}